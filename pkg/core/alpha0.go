@@ -28,6 +28,12 @@ func (t *IdealGasHelmholtzLead) DTau2(tau, delta float64) float64 {
 func (t *IdealGasHelmholtzLead) DDeltaTau(tau, delta float64) float64 {
 	return 0
 }
+func (t *IdealGasHelmholtzLead) DDelta3(tau, delta float64) float64 {
+	return 2.0 / (delta * delta * delta)
+}
+func (t *IdealGasHelmholtzLead) DDelta2Tau(tau, delta float64) float64 {
+	return 0
+}
 
 // IdealGasHelmholtzLogTau: alpha = a * ln(tau)
 type IdealGasHelmholtzLogTau struct {
@@ -52,6 +58,12 @@ func (t *IdealGasHelmholtzLogTau) DTau2(tau, delta float64) float64 {
 func (t *IdealGasHelmholtzLogTau) DDeltaTau(tau, delta float64) float64 {
 	return 0
 }
+func (t *IdealGasHelmholtzLogTau) DDelta3(tau, delta float64) float64 {
+	return 0
+}
+func (t *IdealGasHelmholtzLogTau) DDelta2Tau(tau, delta float64) float64 {
+	return 0
+}
 
 // IdealGasHelmholtzPlanckEinstein: alpha = sum(n_i * ln(1 - exp(-t_i * tau)))
 type IdealGasHelmholtzPlanckEinstein struct {
@@ -92,3 +104,203 @@ func (t *IdealGasHelmholtzPlanckEinstein) DTau2(tau, delta float64) float64 {
 func (t *IdealGasHelmholtzPlanckEinstein) DDeltaTau(tau, delta float64) float64 {
 	return 0
 }
+func (t *IdealGasHelmholtzPlanckEinstein) DDelta3(tau, delta float64) float64 {
+	return 0
+}
+func (t *IdealGasHelmholtzPlanckEinstein) DDelta2Tau(tau, delta float64) float64 {
+	return 0
+}
+
+// IdealGasHelmholtzEnthalpyEntropyOffset: alpha = a1 + a2*tau, which shifts
+// the enthalpy and entropy to the reference state of the fluid file
+type IdealGasHelmholtzEnthalpyEntropyOffset struct {
+	A1 float64
+	A2 float64
+}
+
+func (t *IdealGasHelmholtzEnthalpyEntropyOffset) Term(tau, delta float64) float64 {
+	return t.A1 + t.A2*tau
+}
+func (t *IdealGasHelmholtzEnthalpyEntropyOffset) DDelta(tau, delta float64) float64 {
+	return 0
+}
+func (t *IdealGasHelmholtzEnthalpyEntropyOffset) DTau(tau, delta float64) float64 {
+	return t.A2
+}
+func (t *IdealGasHelmholtzEnthalpyEntropyOffset) DDelta2(tau, delta float64) float64 {
+	return 0
+}
+func (t *IdealGasHelmholtzEnthalpyEntropyOffset) DTau2(tau, delta float64) float64 {
+	return 0
+}
+func (t *IdealGasHelmholtzEnthalpyEntropyOffset) DDeltaTau(tau, delta float64) float64 {
+	return 0
+}
+func (t *IdealGasHelmholtzEnthalpyEntropyOffset) DDelta3(tau, delta float64) float64 {
+	return 0
+}
+func (t *IdealGasHelmholtzEnthalpyEntropyOffset) DDelta2Tau(tau, delta float64) float64 {
+	return 0
+}
+
+// IdealGasHelmholtzPower: alpha = sum(n_i * tau^t_i)
+type IdealGasHelmholtzPower struct {
+	N []float64
+	T []float64
+}
+
+func (t *IdealGasHelmholtzPower) Term(tau, delta float64) float64 {
+	sum := 0.0
+	for i := range t.N {
+		sum += t.N[i] * math.Pow(tau, t.T[i])
+	}
+	return sum
+}
+func (t *IdealGasHelmholtzPower) DDelta(tau, delta float64) float64 {
+	return 0
+}
+func (t *IdealGasHelmholtzPower) DTau(tau, delta float64) float64 {
+	sum := 0.0
+	for i := range t.N {
+		sum += t.N[i] * t.T[i] * math.Pow(tau, t.T[i]-1)
+	}
+	return sum
+}
+func (t *IdealGasHelmholtzPower) DDelta2(tau, delta float64) float64 {
+	return 0
+}
+func (t *IdealGasHelmholtzPower) DTau2(tau, delta float64) float64 {
+	sum := 0.0
+	for i := range t.N {
+		sum += t.N[i] * t.T[i] * (t.T[i] - 1) * math.Pow(tau, t.T[i]-2)
+	}
+	return sum
+}
+func (t *IdealGasHelmholtzPower) DDeltaTau(tau, delta float64) float64 {
+	return 0
+}
+func (t *IdealGasHelmholtzPower) DDelta3(tau, delta float64) float64 {
+	return 0
+}
+func (t *IdealGasHelmholtzPower) DDelta2Tau(tau, delta float64) float64 {
+	return 0
+}
+
+// IdealGasHelmholtzPlanckEinsteinGeneralized:
+// alpha = sum(n_i * ln(c_i + d_i * exp(theta_i * tau)))
+type IdealGasHelmholtzPlanckEinsteinGeneralized struct {
+	N     []float64
+	Theta []float64
+	C     []float64
+	D     []float64
+}
+
+func (t *IdealGasHelmholtzPlanckEinsteinGeneralized) Term(tau, delta float64) float64 {
+	sum := 0.0
+	for i := range t.N {
+		sum += t.N[i] * math.Log(t.C[i]+t.D[i]*math.Exp(t.Theta[i]*tau))
+	}
+	return sum
+}
+func (t *IdealGasHelmholtzPlanckEinsteinGeneralized) DDelta(tau, delta float64) float64 {
+	return 0
+}
+func (t *IdealGasHelmholtzPlanckEinsteinGeneralized) DTau(tau, delta float64) float64 {
+	sum := 0.0
+	for i := range t.N {
+		expVal := math.Exp(t.Theta[i] * tau)
+		sum += t.N[i] * t.Theta[i] * t.D[i] * expVal / (t.C[i] + t.D[i]*expVal)
+	}
+	return sum
+}
+func (t *IdealGasHelmholtzPlanckEinsteinGeneralized) DDelta2(tau, delta float64) float64 {
+	return 0
+}
+func (t *IdealGasHelmholtzPlanckEinsteinGeneralized) DTau2(tau, delta float64) float64 {
+	sum := 0.0
+	for i := range t.N {
+		expVal := math.Exp(t.Theta[i] * tau)
+		denom := t.C[i] + t.D[i]*expVal
+		sum += t.N[i] * t.Theta[i] * t.Theta[i] * t.C[i] * t.D[i] * expVal / (denom * denom)
+	}
+	return sum
+}
+func (t *IdealGasHelmholtzPlanckEinsteinGeneralized) DDeltaTau(tau, delta float64) float64 {
+	return 0
+}
+func (t *IdealGasHelmholtzPlanckEinsteinGeneralized) DDelta3(tau, delta float64) float64 {
+	return 0
+}
+func (t *IdealGasHelmholtzPlanckEinsteinGeneralized) DDelta2Tau(tau, delta float64) float64 {
+	return 0
+}
+
+// IdealGasHelmholtzCP0PolyT is the ideal-gas part of an EOS given as
+// cp0/R = sum(c_i * T^t_i), integrated from the reference temperature T0;
+// tau = Tc/T. A constant cp0/R is the term with t = 0.
+type IdealGasHelmholtzCP0PolyT struct {
+	C  []float64
+	T  []float64
+	Tc float64
+	T0 float64
+}
+
+func (t *IdealGasHelmholtzCP0PolyT) Term(tau, delta float64) float64 {
+	tau0 := t.Tc / t.T0
+	sum := 0.0
+	for i, c := range t.C {
+		switch ti := t.T[i]; {
+		case ti == 0:
+			sum += c - c*tau/tau0 + c*math.Log(tau/tau0)
+		case ti == -1:
+			sum += c*tau/t.Tc*math.Log(tau0/tau) + c/t.Tc*(tau-tau0)
+		default:
+			sum += -c*math.Pow(t.Tc, ti)*math.Pow(tau, -ti)/(ti*(ti+1)) - c*math.Pow(t.T0, ti+1)*tau/(t.Tc*(ti+1)) + c*math.Pow(t.T0, ti)/ti
+		}
+	}
+	return sum
+}
+func (t *IdealGasHelmholtzCP0PolyT) DDelta(tau, delta float64) float64 {
+	return 0
+}
+func (t *IdealGasHelmholtzCP0PolyT) DTau(tau, delta float64) float64 {
+	tau0 := t.Tc / t.T0
+	sum := 0.0
+	for i, c := range t.C {
+		switch ti := t.T[i]; {
+		case ti == 0:
+			sum += c/tau - c/tau0
+		case ti == -1:
+			sum += c / t.Tc * math.Log(tau0/tau)
+		default:
+			sum += c*math.Pow(t.Tc, ti)*math.Pow(tau, -ti-1)/(ti+1) - c*math.Pow(t.Tc, ti)/(math.Pow(tau0, ti+1)*(ti+1))
+		}
+	}
+	return sum
+}
+func (t *IdealGasHelmholtzCP0PolyT) DDelta2(tau, delta float64) float64 {
+	return 0
+}
+func (t *IdealGasHelmholtzCP0PolyT) DTau2(tau, delta float64) float64 {
+	sum := 0.0
+	for i, c := range t.C {
+		switch ti := t.T[i]; {
+		case ti == 0:
+			sum += -c / (tau * tau)
+		case ti == -1:
+			sum += -c / (tau * t.Tc)
+		default:
+			sum += -c * math.Pow(t.Tc/tau, ti) / (tau * tau)
+		}
+	}
+	return sum
+}
+func (t *IdealGasHelmholtzCP0PolyT) DDeltaTau(tau, delta float64) float64 {
+	return 0
+}
+func (t *IdealGasHelmholtzCP0PolyT) DDelta3(tau, delta float64) float64 {
+	return 0
+}
+func (t *IdealGasHelmholtzCP0PolyT) DDelta2Tau(tau, delta float64) float64 {
+	return 0
+}
@@ -112,6 +112,47 @@ func (t *ResidualHelmholtzPower) DDeltaTau(tau, delta float64) float64 {
 	return sum
 }
 
+func (t *ResidualHelmholtzPower) DDelta3(tau, delta float64) float64 {
+	sum := 0.0
+	for i := range t.N {
+		n, d, ti, l := t.N[i], t.D[i], t.T[i], t.L[i]
+		sum += n * math.Pow(tau, ti) * powerDelta3(delta, d, l)
+	}
+	return sum
+}
+
+func (t *ResidualHelmholtzPower) DDelta2Tau(tau, delta float64) float64 {
+	sum := 0.0
+	for i := range t.N {
+		n, d, ti, l := t.N[i], t.D[i], t.T[i], t.L[i]
+		sum += n * ti * math.Pow(tau, ti-1) * powerDelta2(delta, d, l)
+	}
+	return sum
+}
+
+// powerDelta2 returns d²/dδ² [ δ^d · exp(-δ^l) ] (no exp term if l == 0).
+func powerDelta2(delta, d, l float64) float64 {
+	if l == 0 {
+		return d * (d - 1) * math.Pow(delta, d-2)
+	}
+	deltaL := math.Pow(delta, l)
+	bracket := d*(d-1) - l*(2*d+l-1)*deltaL + l*l*deltaL*deltaL
+	return math.Pow(delta, d-2) * math.Exp(-deltaL) * bracket
+}
+
+// powerDelta3 returns d³/dδ³ [ δ^d · exp(-δ^l) ] (no exp term if l == 0).
+func powerDelta3(delta, d, l float64) float64 {
+	if l == 0 {
+		return d * (d - 1) * (d - 2) * math.Pow(delta, d-3)
+	}
+	// With u = δ^l and f'' = δ^(d-2)·exp(-u)·B(u) as in DDelta2:
+	// f''' = δ^(d-3)·exp(-u)·[ (d-2-l·u)·B + δ·dB/dδ ],  δ·dB/dδ = -l²(2d+l-1)u + 2l³u²
+	u := math.Pow(delta, l)
+	B := d*(d-1) - l*(2*d+l-1)*u + l*l*u*u
+	dB := -l*l*(2*d+l-1)*u + 2*l*l*l*u*u
+	return math.Pow(delta, d-3) * math.Exp(-u) * ((d-2-l*u)*B + dB)
+}
+
 // ResidualHelmholtzGaussian: alpha = n * delta^d * tau^t * exp(-eta*(delta-epsilon)^2 - beta*(tau-gamma)^2)
 type ResidualHelmholtzGaussian struct {
 	N       []float64
@@ -232,3 +273,150 @@ func (t *ResidualHelmholtzGaussian) DDeltaTau(tau, delta float64) float64 {
 	}
 	return sum
 }
+
+func (t *ResidualHelmholtzGaussian) DDelta3(tau, delta float64) float64 {
+	sum := 0.0
+	for i := range t.N {
+		d, eta, eps := t.D[i], t.Eta[i], t.Epsilon[i]
+		deltaDiff := delta - eps
+		tauDiff := tau - t.Gamma[i]
+		expVal := math.Exp(-eta*deltaDiff*deltaDiff - t.Beta[i]*tauDiff*tauDiff)
+
+		// f_d = f·A with A = d/δ - 2η(δ-ε), so
+		// f_ddd = f·(A³ + 3·A·A' + A''), A' = -d/δ² - 2η, A'' = 2d/δ³
+		term := t.N[i] * math.Pow(delta, d) * math.Pow(tau, t.T[i]) * expVal
+		A := d/delta - 2*eta*deltaDiff
+		dA := -d/(delta*delta) - 2*eta
+		d2A := 2 * d / (delta * delta * delta)
+
+		sum += term * (A*A*A + 3*A*dA + d2A)
+	}
+	return sum
+}
+
+func (t *ResidualHelmholtzGaussian) DDelta2Tau(tau, delta float64) float64 {
+	sum := 0.0
+	for i := range t.N {
+		d, eta, eps := t.D[i], t.Eta[i], t.Epsilon[i]
+		ti, beta, gamma := t.T[i], t.Beta[i], t.Gamma[i]
+		deltaDiff := delta - eps
+		tauDiff := tau - gamma
+		expVal := math.Exp(-eta*deltaDiff*deltaDiff - beta*tauDiff*tauDiff)
+
+		term := t.N[i] * math.Pow(delta, d) * math.Pow(tau, ti) * expVal
+		bracket1 := d/delta - 2*eta*deltaDiff
+		bracket2 := -d/(delta*delta) - 2*eta
+		bracketTau := ti/tau - 2*beta*tauDiff
+
+		sum += term * (bracket1*bracket1 + bracket2) * bracketTau
+	}
+	return sum
+}
+
+// ResidualHelmholtzExponential:
+// alpha = n * delta^d * tau^t * exp(-g*delta^l) * exp(-tau^m)
+// with no delta exponential if l == 0 and no tau exponential if m == 0.
+// It covers the "ResidualHelmholtzExponential" (m = 0) and
+// "ResidualHelmholtzLemmon2005" (g = 1) terms of the fluid files.
+type ResidualHelmholtzExponential struct {
+	N []float64
+	D []float64
+	T []float64
+	L []float64
+	G []float64
+	M []float64
+}
+
+// factors returns the delta and tau factors of term i and their first
+// three derivatives.
+func (t *ResidualHelmholtzExponential) factors(i int, tau, delta float64) (fd, ft [4]float64) {
+	fd = powExp(delta, t.D[i], t.L[i], t.G[i])
+	ft = powExp(tau, t.T[i], t.M[i], 1)
+	return fd, ft
+}
+
+func (t *ResidualHelmholtzExponential) Term(tau, delta float64) float64 {
+	sum := 0.0
+	for i := range t.N {
+		fd, ft := t.factors(i, tau, delta)
+		sum += t.N[i] * fd[0] * ft[0]
+	}
+	return sum
+}
+func (t *ResidualHelmholtzExponential) DDelta(tau, delta float64) float64 {
+	sum := 0.0
+	for i := range t.N {
+		fd, ft := t.factors(i, tau, delta)
+		sum += t.N[i] * fd[1] * ft[0]
+	}
+	return sum
+}
+func (t *ResidualHelmholtzExponential) DTau(tau, delta float64) float64 {
+	sum := 0.0
+	for i := range t.N {
+		fd, ft := t.factors(i, tau, delta)
+		sum += t.N[i] * fd[0] * ft[1]
+	}
+	return sum
+}
+func (t *ResidualHelmholtzExponential) DDelta2(tau, delta float64) float64 {
+	sum := 0.0
+	for i := range t.N {
+		fd, ft := t.factors(i, tau, delta)
+		sum += t.N[i] * fd[2] * ft[0]
+	}
+	return sum
+}
+func (t *ResidualHelmholtzExponential) DTau2(tau, delta float64) float64 {
+	sum := 0.0
+	for i := range t.N {
+		fd, ft := t.factors(i, tau, delta)
+		sum += t.N[i] * fd[0] * ft[2]
+	}
+	return sum
+}
+func (t *ResidualHelmholtzExponential) DDeltaTau(tau, delta float64) float64 {
+	sum := 0.0
+	for i := range t.N {
+		fd, ft := t.factors(i, tau, delta)
+		sum += t.N[i] * fd[1] * ft[1]
+	}
+	return sum
+}
+func (t *ResidualHelmholtzExponential) DDelta3(tau, delta float64) float64 {
+	sum := 0.0
+	for i := range t.N {
+		fd, ft := t.factors(i, tau, delta)
+		sum += t.N[i] * fd[3] * ft[0]
+	}
+	return sum
+}
+func (t *ResidualHelmholtzExponential) DDelta2Tau(tau, delta float64) float64 {
+	sum := 0.0
+	for i := range t.N {
+		fd, ft := t.factors(i, tau, delta)
+		sum += t.N[i] * fd[2] * ft[1]
+	}
+	return sum
+}
+
+// powExp returns x^a · exp(-g·x^l) and its first three derivatives with
+// respect to x, without the exponential if l or g is 0. It is the
+// generalization of powerDelta2 and powerDelta3 with u = g·x^l.
+func powExp(x, a, l, g float64) [4]float64 {
+	u := 0.0
+	if l != 0 && g != 0 {
+		u = g * math.Pow(x, l)
+	} else {
+		l = 0
+	}
+	e := math.Exp(-u)
+	B := a*(a-1) - l*(2*a+l-1)*u + l*l*u*u
+	dB := -l*l*(2*a+l-1)*u + 2*l*l*l*u*u
+	return [4]float64{
+		math.Pow(x, a) * e,
+		math.Pow(x, a-1) * e * (a - l*u),
+		math.Pow(x, a-2) * e * B,
+		math.Pow(x, a-3) * e * ((a-2-l*u)*B + dB),
+	}
+}
@@ -83,3 +83,136 @@ func TestNitrogenCore(t *testing.T) {
 	P_High := state.Pressure()
 	t.Logf("Pressure at Rho=%v is %v", RhoHigh, P_High)
 }
+
+func TestSecondDensityDerivatives(t *testing.T) {
+	f, err := fluid.LoadFluid("../../data/Water.json")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+	state := NewState(f)
+
+	// Gas, near-critical and liquid states (T in K, rho in mol/m³)
+	for _, pt := range [][2]float64{{500, 50}, {650, 17000}, {300, 55000}} {
+		T, rho := pt[0], pt[1]
+		h := 1e-5 * rho
+
+		state.Update(T, rho+h)
+		dPp, dHp, dUp := state.DPdRho(), state.DHdRho(), state.DUdRho()
+		state.Update(T, rho-h)
+		dPm, dHm, dUm := state.DPdRho(), state.DHdRho(), state.DUdRho()
+		state.Update(T, rho)
+
+		checks := []struct {
+			name          string
+			analytic, num float64
+		}{
+			{"d2P/drho2", state.D2PdRho2(), (dPp - dPm) / (2 * h)},
+			{"d2H/drho2", state.D2HdRho2(), (dHp - dHm) / (2 * h)},
+			{"d2U/drho2", state.D2UdRho2(), (dUp - dUm) / (2 * h)},
+		}
+		for _, c := range checks {
+			if math.Abs(c.analytic-c.num) > 1e-5*math.Abs(c.num)+1e-12 {
+				t.Errorf("T=%v rho=%v: %s analytic %v, numerical %v", T, rho, c.name, c.analytic, c.num)
+			}
+		}
+	}
+}
+
+func TestIdealGasCp(t *testing.T) {
+	// Ideal-gas heat capacities at 298.15 K from the NIST Chemistry WebBook,
+	// covering the cp0 polynomial, power and generalized Planck-Einstein
+	// forms of the ideal-gas part
+	tests := []struct {
+		fluid    string
+		expected float64 // J/(mol*K)
+	}{
+		{"R22", 55.85},
+		{"R143a", 78.2},
+		{"Air", 29.1},
+		{"CycloPropane", 55.6},
+	}
+	for _, tt := range tests {
+		f, err := fluid.LoadFluid("../../data/" + tt.fluid + ".json")
+		if err != nil {
+			t.Fatalf("Failed to load %s: %v", tt.fluid, err)
+		}
+		state := NewState(f)
+		state.Update(298.15, 1e-3)
+		if cp0 := state.IdealGasCp(); math.Abs(cp0-tt.expected)/tt.expected > 0.01 {
+			t.Errorf("%s: cp0 = %v, expected %v", tt.fluid, cp0, tt.expected)
+		}
+	}
+
+	// The tau derivatives of all ideal-gas forms against finite differences
+	for _, name := range []string{"R22", "R124", "Air", "D6"} {
+		f, err := fluid.LoadFluid("../../data/" + name + ".json")
+		if err != nil {
+			t.Fatalf("Failed to load %s: %v", name, err)
+		}
+		state := NewState(f)
+		tau, delta, h := 1.3, 0.01, 1e-5
+		a0p, _, da0p, _, _, _ := state.HE.UpdateIdealGas(tau+h, delta)
+		a0m, _, da0m, _, _, _ := state.HE.UpdateIdealGas(tau-h, delta)
+		_, _, da0, _, d2a0, _ := state.HE.UpdateIdealGas(tau, delta)
+		if num := (a0p - a0m) / (2 * h); math.Abs(da0-num) > 1e-6*math.Abs(num)+1e-8 {
+			t.Errorf("%s: da0/dtau analytic %v, numerical %v", name, da0, num)
+		}
+		if num := (da0p - da0m) / (2 * h); math.Abs(d2a0-num) > 1e-6*math.Abs(num)+1e-8 {
+			t.Errorf("%s: d2a0/dtau2 analytic %v, numerical %v", name, d2a0, num)
+		}
+	}
+}
+
+func TestExponentialResidualTerms(t *testing.T) {
+	// R14 has exponential terms (Platzer and Maurer form), R125 Lemmon
+	// (2005) terms. The EOS must come close to the critical pressure of the
+	// fluid file and its residual part must vanish in the dilute gas.
+	for _, name := range []string{"R14", "R125"} {
+		f, err := fluid.LoadFluid("../../data/" + name + ".json")
+		if err != nil {
+			t.Fatalf("Failed to load %s: %v", name, err)
+		}
+		state := NewState(f)
+		crit := f.States.Critical
+		state.Update(crit.T, crit.RhoMolar)
+		if P := state.Pressure(); math.Abs(P-crit.P)/crit.P > 0.01 {
+			t.Errorf("%s: critical pressure %v, expected %v", name, P, crit.P)
+		}
+
+		state.Update(1.2*crit.T, 1e-4*crit.RhoMolar)
+		ar, ar_d, _, _, _, _ := state.Residual()
+		if Z1 := state.Delta * ar_d; math.Abs(ar-Z1) > 1e-3*math.Abs(Z1) {
+			t.Errorf("%s: dilute gas alphar %v, expected ~delta*alphar_delta = %v", name, ar, Z1)
+		}
+
+		// Derivatives against finite differences
+		tau, delta, h := 1.1, 0.8, 1e-6
+		a := func(tau, delta float64) [6]float64 {
+			a0, a1, a2, a3, a4, a5 := state.HE.UpdateResidual(tau, delta)
+			return [6]float64{a0, a1, a2, a3, a4, a5}
+		}
+		c, dp, dm, tp, tm := a(tau, delta), a(tau, delta+h), a(tau, delta-h), a(tau+h, delta), a(tau-h, delta)
+		var d3, d2t float64
+		for _, term := range state.HE.AlphaR {
+			d3 += term.DDelta3(tau, delta)
+			d2t += term.DDelta2Tau(tau, delta)
+		}
+		checks := []struct {
+			name          string
+			analytic, num float64
+		}{
+			{"ar_delta", c[1], (dp[0] - dm[0]) / (2 * h)},
+			{"ar_tau", c[2], (tp[0] - tm[0]) / (2 * h)},
+			{"ar_delta_delta", c[3], (dp[1] - dm[1]) / (2 * h)},
+			{"ar_tau_tau", c[4], (tp[2] - tm[2]) / (2 * h)},
+			{"ar_delta_tau", c[5], (tp[1] - tm[1]) / (2 * h)},
+			{"ar_delta_delta_delta", d3, (dp[3] - dm[3]) / (2 * h)},
+			{"ar_delta_delta_tau", d2t, (tp[3] - tm[3]) / (2 * h)},
+		}
+		for _, ck := range checks {
+			if math.Abs(ck.analytic-ck.num) > 1e-6*math.Abs(ck.num)+1e-8 {
+				t.Errorf("%s: %s analytic %v, numerical %v", name, ck.name, ck.analytic, ck.num)
+			}
+		}
+	}
+}
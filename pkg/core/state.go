@@ -29,7 +29,7 @@ func NewState(f *fluid.FluidData) *State {
 	// Build HelmholtzEnergy from FluidData
 	he := &HelmholtzEnergy{}
 
-	// Alpha0
+	// Alpha0, the types fluid.LoadFluid accepts
 	for _, term := range f.EOS[0].Alpha0 {
 		switch term.Type {
 		case "IdealGasHelmholtzLead":
@@ -38,6 +38,38 @@ func NewState(f *fluid.FluidData) *State {
 			he.Alpha0 = append(he.Alpha0, &IdealGasHelmholtzLogTau{A: term.A})
 		case "IdealGasHelmholtzPlanckEinstein":
 			he.Alpha0 = append(he.Alpha0, &IdealGasHelmholtzPlanckEinstein{N: term.N, T: term.T})
+		case "IdealGasHelmholtzPlanckEinsteinFunctionT":
+			// Characteristic temperatures v_i in K: t_i = v_i / Tcrit
+			t := make([]float64, len(term.V))
+			for i, v := range term.V {
+				t[i] = v / term.TCrit
+			}
+			he.Alpha0 = append(he.Alpha0, &IdealGasHelmholtzPlanckEinstein{N: term.N, T: t})
+		case "IdealGasHelmholtzPlanckEinsteinGeneralized":
+			he.Alpha0 = append(he.Alpha0, &IdealGasHelmholtzPlanckEinsteinGeneralized{N: term.N, Theta: term.T, C: term.C, D: term.D})
+		case "IdealGasHelmholtzPower":
+			he.Alpha0 = append(he.Alpha0, &IdealGasHelmholtzPower{N: term.N, T: term.T})
+		case "IdealGasHelmholtzEnthalpyEntropyOffset":
+			he.Alpha0 = append(he.Alpha0, &IdealGasHelmholtzEnthalpyEntropyOffset{A1: term.A1, A2: term.A2})
+		case "IdealGasHelmholtzCP0Constant":
+			he.Alpha0 = append(he.Alpha0, &IdealGasHelmholtzCP0PolyT{C: []float64{term.CpOverR}, T: []float64{0}, Tc: term.Tc, T0: term.T0})
+		case "IdealGasHelmholtzCP0PolyT":
+			he.Alpha0 = append(he.Alpha0, &IdealGasHelmholtzCP0PolyT{C: term.C, T: term.T, Tc: term.Tc, T0: term.T0})
+		case "IdealGasHelmholtzCP0AlyLee":
+			// cp0/R = c0 + c1*(c2/T/sinh(c2/T))^2 + c3*(c4/T/cosh(c4/T))^2, the
+			// hyperbolic terms as generalized Planck-Einstein terms
+			c := term.C
+			he.Alpha0 = append(he.Alpha0, &IdealGasHelmholtzCP0PolyT{C: []float64{c[0]}, T: []float64{0}, Tc: term.Tc, T0: term.T0})
+			pe := &IdealGasHelmholtzPlanckEinsteinGeneralized{}
+			if c[1] != 0 {
+				pe.N, pe.Theta = append(pe.N, c[1]), append(pe.Theta, -2*c[2]/term.Tc)
+				pe.C, pe.D = append(pe.C, 1), append(pe.D, -1)
+			}
+			if c[3] != 0 {
+				pe.N, pe.Theta = append(pe.N, -c[3]), append(pe.Theta, -2*c[4]/term.Tc)
+				pe.C, pe.D = append(pe.C, 1), append(pe.D, 1)
+			}
+			he.Alpha0 = append(he.Alpha0, pe)
 		}
 	}
 
@@ -56,6 +88,18 @@ func NewState(f *fluid.FluidData) *State {
 				N: term.N, D: term.D, T: term.T,
 				Eta: term.Eta, Epsilon: term.Epsilon, Beta: term.Beta, Gamma: term.Gamma,
 			})
+		case "ResidualHelmholtzExponential":
+			he.AlphaR = append(he.AlphaR, &ResidualHelmholtzExponential{
+				N: term.N, D: term.D, T: term.T, L: term.L, G: term.G, M: make([]float64, len(term.N)),
+			})
+		case "ResidualHelmholtzLemmon2005":
+			g := make([]float64, len(term.N))
+			for i := range g {
+				g[i] = 1
+			}
+			he.AlphaR = append(he.AlphaR, &ResidualHelmholtzExponential{
+				N: term.N, D: term.D, T: term.T, L: term.L, G: g, M: term.M,
+			})
 		}
 	}
 
@@ -146,6 +190,21 @@ func (s *State) Cp() float64 {
 	return s.Cv() + R*num*num/den
 }
 
+// Residual returns the residual Helmholtz energy αr and its derivatives at
+// the current state.
+func (s *State) Residual() (ar, dar_ddelta, dar_dtau, d2ar_ddelta2, d2ar_dtau2, d2ar_ddelta_dtau float64) {
+	return s.HE.UpdateResidual(s.Tau, s.Delta)
+}
+
+// IdealGasCp returns the molar isobaric heat capacity of the ideal gas at
+// the current temperature.
+func (s *State) IdealGasCp() float64 {
+	R := s.Fluid.EOS[0].GasConstant
+	// Cp0 = R * (1 - tau^2 * alpha0_tau2)
+	_, _, _, _, a0_tt, _ := s.HE.UpdateIdealGas(s.Tau, s.Delta)
+	return R * (1 - s.Tau*s.Tau*a0_tt)
+}
+
 // Property derivatives for flash algorithms
 
 // DPdT returns ∂P/∂T at constant ρ
@@ -177,16 +236,78 @@ func (s *State) DPdRho() float64 {
 	return R*s.T*s.Delta*s.DaDDelta + s.Rho*R*s.T*(s.DaDDelta+s.Delta*s.D2aDDelta2)/Rhoc
 }
 
+// D2PdRho2 returns ∂²P/∂ρ² at constant T
+func (s *State) D2PdRho2() float64 {
+	R := s.Fluid.EOS[0].GasConstant
+	Rhoc := s.Fluid.EOS[0].States.Critical.RhoMolar
+	if Rhoc == 0 {
+		Rhoc = s.Fluid.States.Critical.RhoMolar
+	}
+
+	// P = RTρc·δ²·α_δ
+	// ∂²P/∂ρ² = RT·(2·α_δ + 4δ·α_δδ + δ²·α_δδδ)/ρc
+	d3a, _ := s.HE.ThirdDerivatives(s.Tau, s.Delta)
+	return R * s.T * (2*s.DaDDelta + 4*s.Delta*s.D2aDDelta2 + s.Delta*s.Delta*d3a) / Rhoc
+}
+
 // DHdT returns ∂H/∂T at constant ρ
 func (s *State) DHdT() float64 {
-	// This is actually Cp!
-	return s.Cp()
+	// H = U + P/ρ
+	// ∂H/∂T = ∂U/∂T + (1/ρ)·∂P/∂T = Cv + (1/ρ)·∂P/∂T
+	return s.Cv() + s.DPdT()/s.Rho
 }
 
 // DHdRho returns ∂H/∂ρ at constant T
 func (s *State) DHdRho() float64 {
 	// H = RT(τ·α_τ + δ·α_δ)
-	// ∂H/∂ρ = RT·∂(δ·α_δ)/∂ρ = RT·(α_δ + δ·α_δδ)·(1/ρc)
+	// ∂H/∂ρ = RT·∂(τ·α_τ + δ·α_δ)/∂ρ = RT·(τ·α_τδ + α_δ + δ·α_δδ)·(1/ρc)
+
+	R := s.Fluid.EOS[0].GasConstant
+	Rhoc := s.Fluid.EOS[0].States.Critical.RhoMolar
+	if Rhoc == 0 {
+		Rhoc = s.Fluid.States.Critical.RhoMolar
+	}
+
+	return R * s.T * (s.Tau*s.D2aDDeltaDTau + s.DaDDelta + s.Delta*s.D2aDDelta2) / Rhoc
+}
+
+// D2HdRho2 returns ∂²H/∂ρ² at constant T
+func (s *State) D2HdRho2() float64 {
+	// ∂²H/∂ρ² = RT·(τ·α_τδδ + 2·α_δδ + δ·α_δδδ)/ρc²
+
+	R := s.Fluid.EOS[0].GasConstant
+	Rhoc := s.Fluid.EOS[0].States.Critical.RhoMolar
+	if Rhoc == 0 {
+		Rhoc = s.Fluid.States.Critical.RhoMolar
+	}
+
+	d3a, d3aTau := s.HE.ThirdDerivatives(s.Tau, s.Delta)
+	return R * s.T * (s.Tau*d3aTau + 2*s.D2aDDelta2 + s.Delta*d3a) / (Rhoc * Rhoc)
+}
+
+// DUdT returns ∂U/∂T at constant ρ
+func (s *State) DUdT() float64 {
+	// This is Cv by definition
+	return s.Cv()
+}
+
+// DUdRho returns ∂U/∂ρ at constant T
+func (s *State) DUdRho() float64 {
+	// U = RT·τ·α_τ
+	// ∂U/∂ρ = RT·τ·α_τδ·(1/ρc)
+
+	R := s.Fluid.EOS[0].GasConstant
+	Rhoc := s.Fluid.EOS[0].States.Critical.RhoMolar
+	if Rhoc == 0 {
+		Rhoc = s.Fluid.States.Critical.RhoMolar
+	}
+
+	return R * s.T * s.Tau * s.D2aDDeltaDTau / Rhoc
+}
+
+// D2UdRho2 returns ∂²U/∂ρ² at constant T
+func (s *State) D2UdRho2() float64 {
+	// ∂²U/∂ρ² = RT·τ·α_τδδ/ρc²
 
 	R := s.Fluid.EOS[0].GasConstant
 	Rhoc := s.Fluid.EOS[0].States.Critical.RhoMolar
@@ -194,7 +315,8 @@ func (s *State) DHdRho() float64 {
 		Rhoc = s.Fluid.States.Critical.RhoMolar
 	}
 
-	return R * s.T * (s.DaDDelta + s.Delta*s.D2aDDelta2) / Rhoc
+	_, d3aTau := s.HE.ThirdDerivatives(s.Tau, s.Delta)
+	return R * s.T * s.Tau * d3aTau / (Rhoc * Rhoc)
 }
 
 // DSdT returns ∂S/∂T at constant ρ
@@ -7,6 +7,8 @@ type HelmholtzTerm interface {
 	DDelta2(tau, delta float64) float64
 	DTau2(tau, delta float64) float64
 	DDeltaTau(tau, delta float64) float64
+	DDelta3(tau, delta float64) float64
+	DDelta2Tau(tau, delta float64) float64
 }
 
 type HelmholtzEnergy struct {
@@ -33,3 +35,39 @@ func (h *HelmholtzEnergy) Update(tau, delta float64) (a, da_ddelta, da_dtau, d2a
 	}
 	return
 }
+
+// UpdateIdealGas returns the ideal-gas part α0 and its derivatives.
+func (h *HelmholtzEnergy) UpdateIdealGas(tau, delta float64) (a0, da0_ddelta, da0_dtau, d2a0_ddelta2, d2a0_dtau2, d2a0_ddelta_dtau float64) {
+	return sumTerms(h.Alpha0, tau, delta)
+}
+
+// UpdateResidual returns the residual part αr and its derivatives.
+func (h *HelmholtzEnergy) UpdateResidual(tau, delta float64) (ar, dar_ddelta, dar_dtau, d2ar_ddelta2, d2ar_dtau2, d2ar_ddelta_dtau float64) {
+	return sumTerms(h.AlphaR, tau, delta)
+}
+
+func sumTerms(terms []HelmholtzTerm, tau, delta float64) (a, da_ddelta, da_dtau, d2a_ddelta2, d2a_dtau2, d2a_ddelta_dtau float64) {
+	for _, term := range terms {
+		a += term.Term(tau, delta)
+		da_ddelta += term.DDelta(tau, delta)
+		da_dtau += term.DTau(tau, delta)
+		d2a_ddelta2 += term.DDelta2(tau, delta)
+		d2a_dtau2 += term.DTau2(tau, delta)
+		d2a_ddelta_dtau += term.DDeltaTau(tau, delta)
+	}
+	return
+}
+
+// ThirdDerivatives returns α_δδδ and α_δδτ, which are only needed for the
+// second density derivatives of P and H, so Update does not compute them.
+func (h *HelmholtzEnergy) ThirdDerivatives(tau, delta float64) (d3a_ddelta3, d3a_ddelta2_dtau float64) {
+	for _, term := range h.Alpha0 {
+		d3a_ddelta3 += term.DDelta3(tau, delta)
+		d3a_ddelta2_dtau += term.DDelta2Tau(tau, delta)
+	}
+	for _, term := range h.AlphaR {
+		d3a_ddelta3 += term.DDelta3(tau, delta)
+		d3a_ddelta2_dtau += term.DDelta2Tau(tau, delta)
+	}
+	return
+}
@@ -0,0 +1,121 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/fluid"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Error kinds of a failed flash or property call. An *Error matches its kind
+// with errors.Is, e.g. errors.Is(err, ErrNoConvergence).
+var (
+	// ErrOutOfRange: the inputs lie outside the range where the state is
+	// defined, e.g. a quality outside [0, 1] or an imposed phase the inputs
+	// do not belong to
+	ErrOutOfRange = errors.New("input out of range")
+	// ErrUnsupportedPair: no flash exists for the input pair
+	ErrUnsupportedPair = errors.New("unsupported input pair")
+	// ErrNoConvergence: the solver found no state matching the inputs
+	ErrNoConvergence = errors.New("no convergence")
+	// ErrTwoPhaseUndefined: the output is not defined for a saturated mixture
+	ErrTwoPhaseUndefined = errors.New("undefined for two-phase states")
+)
+
+// Input is a named input value of a failed call.
+type Input struct {
+	Name  string
+	Value float64
+}
+
+// Error describes a failed flash or property call. Use errors.As to read
+// the diagnostics.
+type Error struct {
+	Kind   error   // one of the error kinds above
+	Op     string  // function that failed, e.g. "FlashPH"
+	Fluid  string  // fluid name
+	Inputs []Input // inputs of the call
+	Detail string  // what went wrong, if more than Kind says
+
+	// EOS validity limit exceeded, for ErrOutOfRange errors of CheckRange:
+	// "T_min", "T_max", "p_min" or "p_max", and its value (K or Pa)
+	Limit      string
+	LimitValue float64
+
+	// Diagnostics of the last Newton iteration, if one was run
+	T, Rho     float64   // last iterate (K, mol/m³)
+	Iterations int       // Newton iterations over all starting points
+	Residuals  []float64 // residuals at the last iterate
+
+	Err error // underlying error, if any
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Op)
+	if e.Fluid != "" {
+		fmt.Fprintf(&b, " (%s)", e.Fluid)
+	}
+	fmt.Fprintf(&b, ": %v", e.Kind)
+	for i, in := range e.Inputs {
+		sep := ", "
+		if i == 0 {
+			sep = " for "
+		}
+		fmt.Fprintf(&b, "%s%s=%v", sep, in.Name, in.Value)
+	}
+	if e.Detail != "" {
+		fmt.Fprintf(&b, ": %s", e.Detail)
+	}
+	if e.Iterations > 0 || e.Residuals != nil {
+		fmt.Fprintf(&b, " (last iterate T=%v, rho=%v, residuals %v after %d iterations)", e.T, e.Rho, e.Residuals, e.Iterations)
+	}
+	if e.Err != nil {
+		fmt.Fprintf(&b, ": %v", e.Err)
+	}
+	return b.String()
+}
+
+// Unwrap returns the error kind and the underlying error, so that errors.Is
+// and errors.As see both.
+func (e *Error) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// newError returns an *Error of the given kind for a call with two inputs.
+func newError(kind error, op string, fluidData *fluid.FluidData, name1 string, val1 float64, name2 string, val2 float64, detail string) *Error {
+	e := &Error{
+		Kind:   kind,
+		Op:     op,
+		Inputs: []Input{{name1, val1}, {name2, val2}},
+		Detail: detail,
+	}
+	if fluidData != nil {
+		e.Fluid = fluidData.Info.Name
+	}
+	return e
+}
+
+// diagnostics records the last Newton iteration of a flash.
+type diagnostics struct {
+	T, Rho     float64
+	Iterations int
+	Residuals  []float64
+	Err        error
+}
+
+// noConvergence returns an ErrNoConvergence error with the diagnostics of
+// the last Newton iteration.
+func (o options) noConvergence(op string, fluidData *fluid.FluidData, name1 string, val1 float64, name2 string, val2 float64) *Error {
+	e := newError(ErrNoConvergence, op, fluidData, name1, val1, name2, val2, "")
+	if d := o.diag; d != nil {
+		e.T, e.Rho = d.T, d.Rho
+		e.Iterations = d.Iterations
+		e.Residuals = d.Residuals
+		e.Err = d.Err
+	}
+	return e
+}
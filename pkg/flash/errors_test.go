@@ -0,0 +1,50 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/phase"
+	"errors"
+	"testing"
+)
+
+func TestErrors_Kinds(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	// Quality outside [0, 1]
+	_, _, err = FlashHQ(f, 40000, 1.5)
+	var fe *Error
+	if !errors.Is(err, ErrOutOfRange) || !errors.As(err, &fe) {
+		t.Fatalf("Expected an ErrOutOfRange *Error, got %v", err)
+	}
+	if fe.Op != "FlashHQ" || fe.Fluid != "Water" || len(fe.Inputs) != 2 || fe.Inputs[1].Value != 1.5 {
+		t.Errorf("Unexpected error fields: %+v", fe)
+	}
+
+	// Superheated steam is not a two-phase state
+	_, _, _, err = FlashPH(f, 101325, 50000, WithPhase(phase.TwoPhase))
+	if !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange, got %v", err)
+	}
+
+	// No state has this enthalpy: the diagnostics of the last Newton
+	// iteration are reported
+	_, _, _, err = FlashPH(f, 101325, -1e9)
+	if !errors.Is(err, ErrNoConvergence) || !errors.As(err, &fe) {
+		t.Fatalf("Expected an ErrNoConvergence *Error, got %v", err)
+	}
+	t.Logf("%v", err)
+	if fe.Iterations == 0 || len(fe.Residuals) != 2 || fe.Err == nil {
+		t.Errorf("Missing diagnostics: %+v", fe)
+	}
+	if errors.Is(err, ErrOutOfRange) {
+		t.Errorf("ErrNoConvergence error also matches ErrOutOfRange")
+	}
+
+	// Spinodal above Tc
+	if _, _, err := Spinodal(f, 700); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange from Spinodal, got %v", err)
+	}
+}
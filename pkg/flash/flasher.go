@@ -0,0 +1,70 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/fluid"
+)
+
+// Flasher runs a sequence of flashes on one fluid, such as the marching
+// steps along a pipe or heat exchanger, and starts each single-phase solve
+// from the previous result.
+type Flasher struct {
+	Fluid *fluid.FluidData
+
+	// Options applied to every flash, e.g. WithPhase
+	Options []Option
+
+	// Newton iterations taken by the last flash
+	Iterations int
+
+	// Last single-phase result, used as the next initial guess
+	T, Rho   float64
+	hasGuess bool
+}
+
+// NewFlasher returns a Flasher for fluidData with the given options applied
+// to every flash.
+func NewFlasher(fluidData *fluid.FluidData, opts ...Option) *Flasher {
+	return &Flasher{Fluid: fluidData, Options: opts}
+}
+
+// Reset discards the previous result, so the next flash starts cold.
+func (fl *Flasher) Reset() {
+	fl.T, fl.Rho = 0, 0
+	fl.hasGuess = false
+}
+
+// PH is FlashPH warm-started from the previous result.
+func (fl *Flasher) PH(P, H float64) (float64, float64, float64, error) {
+	T, Rho, Q, err := FlashPH(fl.Fluid, P, H, fl.options()...)
+	fl.update(T, Rho, Q, err)
+	return T, Rho, Q, err
+}
+
+// PS is FlashPS warm-started from the previous result.
+func (fl *Flasher) PS(P, S float64) (float64, float64, float64, error) {
+	T, Rho, Q, err := FlashPS(fl.Fluid, P, S, fl.options()...)
+	fl.update(T, Rho, Q, err)
+	return T, Rho, Q, err
+}
+
+func (fl *Flasher) options() []Option {
+	fl.Iterations = 0
+	opts := append([]Option{}, fl.Options...)
+	opts = append(opts, withIterationCounter(&fl.Iterations))
+	if fl.hasGuess {
+		opts = append(opts, WithGuess(fl.T, fl.Rho))
+	}
+	return opts
+}
+
+// update keeps a single-phase result as the next guess. A mixture density
+// lies inside the dome and makes a poor starting point, so two-phase results
+// and failures fall back to the flash's own guesses.
+func (fl *Flasher) update(T, Rho, Q float64, err error) {
+	if err != nil || (Q >= 0 && Q <= 1) {
+		fl.Reset()
+		return
+	}
+	fl.T, fl.Rho = T, Rho
+	fl.hasGuess = true
+}
@@ -0,0 +1,132 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/saturation"
+	"math"
+	"testing"
+)
+
+// evaporatorPath returns n+1 enthalpies along a discretised evaporator at
+// pressure P, from subcooled liquid through the dome to superheated vapour.
+func evaporatorPath(tb testing.TB, f *fluid.FluidData, P float64, n int) []float64 {
+	tb.Helper()
+
+	Tsat, err := saturation.Tsat(f, P)
+	if err != nil {
+		tb.Fatalf("Tsat failed: %v", err)
+	}
+	sat, err := saturatedStates(core.NewState(f), Tsat)
+	if err != nil {
+		tb.Fatalf("saturatedStates failed: %v", err)
+	}
+
+	dH := sat.HV - sat.HL
+	Hin := sat.HL - 0.2*dH
+	Hout := sat.HV + 0.3*dH
+
+	H := make([]float64, n+1)
+	for i := range H {
+		H[i] = Hin + (Hout-Hin)*float64(i)/float64(n)
+	}
+	return H
+}
+
+func TestFlasher_Evaporator(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	P := 1e6
+	fl := NewFlasher(f)
+	cold, warm := 0, 0
+
+	for _, H := range evaporatorPath(t, f, P, 50) {
+		T0, Rho0, Q0, err := FlashPH(f, P, H, withIterationCounter(&cold))
+		if err != nil {
+			t.Fatalf("FlashPH failed for H=%v: %v", H, err)
+		}
+
+		T, Rho, Q, err := fl.PH(P, H)
+		if err != nil {
+			t.Fatalf("Flasher.PH failed for H=%v: %v", H, err)
+		}
+		warm += fl.Iterations
+
+		// Warm and cold starts converge to the same state
+		if math.Abs(T-T0) > 1e-6*T0 || math.Abs(Rho-Rho0) > 1e-6*Rho0 || Q != Q0 {
+			t.Errorf("H=%v: warm start gave T=%v, Rho=%v, Q=%v; cold start T=%v, Rho=%v, Q=%v",
+				H, T, Rho, Q, T0, Rho0, Q0)
+		}
+	}
+
+	t.Logf("Newton iterations along the evaporator: cold %d, warm %d", cold, warm)
+	if warm >= cold {
+		t.Errorf("Warm start took %d Newton iterations, cold start %d", warm, cold)
+	}
+}
+
+func TestFlasher_PS(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Nitrogen", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Nitrogen: %v", err)
+	}
+
+	// Isentropic compression of gas, one stage after the other
+	state := core.NewState(f)
+	state.Update(300, 40.6)
+	S := state.MolarEntropy()
+
+	fl := NewFlasher(f)
+	for _, P := range []float64{1e5, 2e5, 4e5, 8e5, 16e5} {
+		T, Rho, Q, err := fl.PS(P, S)
+		if err != nil {
+			t.Fatalf("Flasher.PS failed for P=%v: %v", P, err)
+		}
+		state.Update(T, Rho)
+		t.Logf("P=%v Pa: T=%v K, Rho=%v mol/m³, Q=%v, %d iterations", P, T, Rho, Q, fl.Iterations)
+
+		if math.Abs(state.Pressure()-P) > 1e-3 || math.Abs(state.MolarEntropy()-S) > 1e-6 {
+			t.Errorf("P=%v: result does not reproduce P, S", P)
+		}
+	}
+}
+
+func BenchmarkFlashPH_Evaporator_Cold(b *testing.B) {
+	benchmarkEvaporator(b, false)
+}
+
+func BenchmarkFlashPH_Evaporator_Warm(b *testing.B) {
+	benchmarkEvaporator(b, true)
+}
+
+// benchmarkEvaporator marches FlashPH along an evaporator and reports the
+// Newton iterations per pass, with or without warm starts.
+func benchmarkEvaporator(b *testing.B, warm bool) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		b.Fatalf("Failed to load Water: %v", err)
+	}
+
+	P := 1e6
+	path := evaporatorPath(b, f, P, 200)
+	iterations := 0
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fl := NewFlasher(f)
+		for _, H := range path {
+			if warm {
+				if _, _, _, err := fl.PH(P, H); err != nil {
+					b.Fatal(err)
+				}
+				iterations += fl.Iterations
+			} else if _, _, _, err := FlashPH(f, P, H, withIterationCounter(&iterations)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	b.ReportMetric(float64(iterations)/float64(b.N), "newton-iters/op")
+}
@@ -0,0 +1,172 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/phase"
+	"GOcoolprop/pkg/solver"
+	"math"
+)
+
+// FlashHS solves for Temperature and Density given Enthalpy and Entropy.
+// Returns T (K), Rho (mol/m³) and the vapour quality Q.
+// Q is -1 for single-phase states. See WithPhase for imposing the phase.
+func FlashHS(fluidData *fluid.FluidData, H_target, S_target float64, opts ...Option) (float64, float64, float64, error) {
+	o := collectOptions(opts)
+	T, Rho, Q, err := flashHS(fluidData, H_target, S_target, o)
+	if err == nil {
+		err = o.checkRange(fluidData, "FlashHS", "H", H_target, "S", S_target, T, singlePhasePressure(fluidData, T, Rho, Q))
+	}
+	if err == nil {
+		err = o.checkSaturated(fluidData, "FlashHS", "H", H_target, "S", S_target, T, Q)
+	}
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return T, Rho, Q, nil
+}
+
+// flashHS is FlashHS without the range checks.
+func flashHS(fluidData *fluid.FluidData, H_target, S_target float64, o options) (float64, float64, float64, error) {
+	state := core.NewState(fluidData)
+
+	Tmin, Tmax := saturationRange(fluidData)
+
+	// ---- Two-phase check ----
+	// A mixture lies on the straight line between the saturated liquid and
+	// vapour in the (h, s) plane, so the quality from H and the quality from
+	// S agree at its saturation temperature:
+	// (H - hL)·(sV - sL) = (S - sL)·(hV - hL)
+	// Scan for roots and keep the one with 0 <= Q <= 1. An imposed single
+	// phase skips the scan.
+	twoPhase := func(T float64) float64 {
+		sat, err := saturatedStates(state, T)
+		if err != nil {
+			return math.NaN()
+		}
+		return (H_target-sat.HL)*(sat.SV-sat.SL) - (S_target-sat.SL)*(sat.HV-sat.HL)
+	}
+
+	const nScan = 100
+	dT := (Tmax - Tmin) / float64(nScan)
+	prevT := Tmin
+	prevVal := math.NaN()
+	if o.checkTwoPhase() {
+		prevVal = twoPhase(prevT)
+	}
+	for i := 1; i <= nScan && o.checkTwoPhase(); i++ {
+		T := Tmin + dT*float64(i)
+		if i == nScan {
+			// Stay just below the critical point where the phases merge
+			T = Tmax - 1e-6*Tmax
+		}
+		val := twoPhase(T)
+
+		if prevVal*val <= 0 && !math.IsNaN(prevVal) && !math.IsNaN(val) {
+			Tsat, err := solver.Brent(twoPhase, prevT, T, 1e-9)
+			if err == nil {
+				sat, err := saturatedStates(state, Tsat)
+				if err == nil {
+					Q := (S_target - sat.SL) / (sat.SV - sat.SL)
+					if Q >= 0 && Q <= 1 {
+						if o.returnTwoPhase() {
+							return Tsat, mixtureDensity(sat.RhoL, sat.RhoV, Q), Q, nil
+						}
+						// Metastable root: solve on its branch below
+						o.metastableBranch(Q)
+						break
+					}
+				}
+			}
+		}
+
+		prevT, prevVal = T, val
+	}
+
+	if o.phase == phase.TwoPhase {
+		return 0, 0, 0, newError(ErrOutOfRange, "FlashHS", fluidData, "H", H_target, "S", S_target, "not a two-phase state")
+	}
+
+	// ---- Single phase: 2D Newton in (T, rho) ----
+	funcJS := func(T, Rho float64) (f1, f2, J11, J12, J21, J22 float64) {
+		state.Update(T, Rho)
+
+		// Residuals
+		f1 = state.MolarEnthalpy() - H_target
+		f2 = state.MolarEntropy() - S_target
+
+		// Jacobian elements
+		J11 = state.DHdT()
+		J12 = state.DHdRho()
+		J21 = state.DSdT()
+		J22 = state.DSdRho()
+
+		return
+	}
+
+	// ---- Warm start from a previous solution ----
+	if o.guess != nil {
+		if T, Rho, ok := o.newtonRoot(fluidData, funcJS, *o.guess, o.checkDome()); ok {
+			return T, Rho, -1, nil
+		}
+	}
+
+	for _, guess := range hsInitialGuesses(fluidData, state, o, H_target, S_target, Tmin, Tmax) {
+		if T, Rho, ok := o.newtonRoot(fluidData, funcJS, guess, o.checkDome()); ok {
+			return T, Rho, -1, nil
+		}
+	}
+
+	return 0, 0, 0, o.noConvergence("FlashHS", fluidData, "H", H_target, "S", S_target)
+}
+
+// hsInitialGuesses builds starting points (T, rho) for the single-phase
+// H-S Newton iteration, most promising first.
+func hsInitialGuesses(fluidData *fluid.FluidData, state *core.State, o options, H_target, S_target, Tmin, Tmax float64) [][2]float64 {
+	R := fluidData.EOS[0].GasConstant
+	Tc := fluidData.States.Critical.T
+	Rhoc := fluidData.States.Critical.RhoMolar
+
+	guesses := make([][2]float64, 0, 4)
+
+	// Entropy at the critical point splits the saturation curve into its
+	// liquid and vapour branches.
+	state.Update(Tc, Rhoc)
+	Sc := state.MolarEntropy()
+
+	// Find the saturated state on the matching branch with the target entropy.
+	liquid := o.liquidBranch(S_target < Sc)
+	branch := func(sat satStates) float64 { return sat.SV }
+	if liquid {
+		branch = func(sat satStates) float64 { return sat.SL }
+	}
+	Tstar := branchTemperature(state, branch, S_target, Tmin, Tmax)
+
+	if sat, err := saturatedStates(state, Tstar); err == nil {
+		if liquid {
+			// Compressed liquid along an isentrope barely changes temperature
+			guesses = append(guesses, [2]float64{Tstar, sat.RhoL})
+		} else {
+			// March from the saturated vapour as an ideal gas:
+			// dH = Cp·dT and dS = Cv·dT/T - R·dρ/ρ
+			state.Update(Tstar, sat.RhoV)
+			Cp := state.Cp()
+			Cv := state.Cv()
+
+			T0 := Tstar + (H_target-sat.HV)/Cp
+			if T0 < 0.5*Tstar {
+				T0 = 0.5 * Tstar
+			}
+			Rho0 := sat.RhoV * math.Exp((Cv*math.Log(T0/Tstar)-(S_target-sat.SV))/R)
+
+			guesses = append(guesses, [2]float64{T0, Rho0})
+			guesses = append(guesses, [2]float64{Tstar, sat.RhoV})
+		}
+	}
+
+	// Supercritical fallbacks around the critical point
+	guesses = append(guesses, [2]float64{1.2 * Tc, Rhoc})
+	guesses = append(guesses, [2]float64{2.0 * Tc, 0.1 * Rhoc})
+
+	return guesses
+}
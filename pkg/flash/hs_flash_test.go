@@ -0,0 +1,127 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/saturation"
+	"math"
+	"testing"
+)
+
+func TestFlashHS_Nitrogen_Gas(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Nitrogen", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Nitrogen: %v", err)
+	}
+
+	// Target state: 300K, 1 atm
+	T_expected := 300.0
+	rho_setup := 40.6
+
+	state := core.NewState(f)
+	state.Update(T_expected, rho_setup)
+	H_target := state.MolarEnthalpy()
+	S_target := state.MolarEntropy()
+
+	t.Logf("Target: H=%v J/mol, S=%v J/mol/K (at T=%v, rho=%v)", H_target, S_target, T_expected, rho_setup)
+
+	// FlashHS
+	T_calc, Rho_calc, Q_calc, err := FlashHS(f, H_target, S_target)
+	if err != nil {
+		t.Fatalf("FlashHS failed: %v", err)
+	}
+
+	t.Logf("Result: T=%v K, Rho=%v mol/m³, Q=%v", T_calc, Rho_calc, Q_calc)
+
+	if math.Abs(T_calc-T_expected) > 0.1 {
+		t.Errorf("Temperature mismatch: got %v, expected %v", T_calc, T_expected)
+	}
+
+	if math.Abs(Rho_calc-rho_setup) > 0.1 {
+		t.Errorf("Density mismatch: got %v, expected %v", Rho_calc, rho_setup)
+	}
+
+	if Q_calc != -1 {
+		t.Errorf("Expected single-phase quality -1, got %v", Q_calc)
+	}
+}
+
+func TestFlashHS_Water_Liquid(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	// Target state: 300K, compressed liquid
+	T_expected := 300.0
+	rho_setup := 55500.0
+
+	state := core.NewState(f)
+	state.Update(T_expected, rho_setup)
+	H_target := state.MolarEnthalpy()
+	S_target := state.MolarEntropy()
+
+	t.Logf("Target: H=%v J/mol, S=%v J/mol/K (at T=%v, rho=%v)", H_target, S_target, T_expected, rho_setup)
+
+	// FlashHS
+	T_calc, Rho_calc, _, err := FlashHS(f, H_target, S_target)
+	if err != nil {
+		t.Fatalf("FlashHS failed: %v", err)
+	}
+
+	t.Logf("Result: T=%v K, Rho=%v mol/m³", T_calc, Rho_calc)
+
+	if math.Abs(T_calc-T_expected) > 0.1 {
+		t.Errorf("Temperature mismatch: got %v, expected %v", T_calc, T_expected)
+	}
+
+	if math.Abs(Rho_calc-rho_setup) > 100.0 {
+		t.Errorf("Density mismatch: got %v, expected %v", Rho_calc, rho_setup)
+	}
+}
+
+func TestFlashHS_Water_TwoPhase(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	// Target state: wet steam at 400 K with 30% quality
+	T_expected := 400.0
+	Q_expected := 0.3
+
+	rhoL, _ := saturation.RhoL(f, T_expected)
+	rhoV, _ := saturation.RhoV(f, T_expected)
+
+	state := core.NewState(f)
+	state.Update(T_expected, rhoL)
+	HL, SL := state.MolarEnthalpy(), state.MolarEntropy()
+	state.Update(T_expected, rhoV)
+	HV, SV := state.MolarEnthalpy(), state.MolarEntropy()
+
+	H_target := HL + Q_expected*(HV-HL)
+	S_target := SL + Q_expected*(SV-SL)
+
+	t.Logf("Target: H=%v J/mol, S=%v J/mol/K (at T=%v, Q=%v)", H_target, S_target, T_expected, Q_expected)
+
+	// FlashHS
+	T_calc, Rho_calc, Q_calc, err := FlashHS(f, H_target, S_target)
+	if err != nil {
+		t.Fatalf("FlashHS failed: %v", err)
+	}
+
+	t.Logf("Result: T=%v K, Rho=%v mol/m³, Q=%v", T_calc, Rho_calc, Q_calc)
+
+	if math.Abs(T_calc-T_expected) > 0.01 {
+		t.Errorf("Temperature mismatch: got %v, expected %v", T_calc, T_expected)
+	}
+
+	if math.Abs(Q_calc-Q_expected) > 1e-4 {
+		t.Errorf("Quality mismatch: got %v, expected %v", Q_calc, Q_expected)
+	}
+
+	rho_expected := 1.0 / (Q_expected/rhoV + (1-Q_expected)/rhoL)
+	if math.Abs(Rho_calc-rho_expected)/rho_expected > 1e-4 {
+		t.Errorf("Density mismatch: got %v, expected %v", Rho_calc, rho_expected)
+	}
+}
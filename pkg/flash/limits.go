@@ -0,0 +1,176 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"fmt"
+	"log"
+	"math"
+)
+
+// RangePolicy selects how states outside the validity range of the EOS are
+// handled.
+type RangePolicy int
+
+const (
+	// RangeError rejects the state with an ErrOutOfRange error (the default
+	// of PropSI)
+	RangeError RangePolicy = iota
+	// RangeWarn accepts the state and passes the range error to RangeWarning
+	RangeWarn
+	// RangeExtrapolate accepts the state silently
+	RangeExtrapolate
+)
+
+// RangeWarning receives the range errors of states accepted under
+// RangeWarn. It logs them by default.
+var RangeWarning = func(err error) {
+	log.Printf("warning: %v", err)
+}
+
+// WithRangePolicy sets how a flash handles inputs and results outside the
+// validity range of the EOS; see CheckRange and CheckSaturationRange.
+// FlashPH, FlashPS, FlashPU, FlashHS, FlashTH and FlashTU check their T and
+// P inputs before solving, and the T and single-phase P, or the T of a
+// saturated mixture, of the result after. Without this option the flashes
+// do not check and extrapolate as before. With WithMetastable, negative
+// pressures (liquid under tension) are accepted.
+func WithRangePolicy(p RangePolicy) Option {
+	return func(o *options) {
+		o.rangePolicy = p
+		o.rangeChecked = true
+	}
+}
+
+// CheckRange checks a temperature (K) and pressure (Pa) against the validity
+// limits of the EOS: T between the triple point temperature ("T_min") and
+// "T_max", P positive ("p_min") and at most "p_max". Pass NaN to skip a
+// variable; limits missing from the fluid file are not checked.
+// It returns nil, or an ErrOutOfRange *Error naming the first limit
+// exceeded in its Limit field.
+func CheckRange(fluidData *fluid.FluidData, T, P float64) *Error {
+	eos := fluidData.EOS[0]
+	Tmin := eos.TTriple
+	if Tmin == 0 {
+		Tmin = fluidData.States.TripleLiquid.T
+	}
+
+	return checkLimits(fluidData, []rangeLimit{
+		{"T_min", T, Tmin, Tmin > 0 && T < Tmin},
+		{"T_max", T, eos.TMax, eos.TMax > 0 && T > eos.TMax},
+		{"p_min", P, 0, P <= 0},
+		{"p_max", P, eos.PMax, eos.PMax > 0 && P > eos.PMax},
+	})
+}
+
+// CheckSaturationRange checks the temperature (K) of a saturated state
+// against the range of the saturation ancillaries pS, rhoL and rhoV of the
+// fluid file: at least the largest of their Tmin ("T_min_ancillary") and
+// at most the smallest of their Tmax ("T_max_ancillary"). Pass NaN to skip
+// the check. It returns nil or an ErrOutOfRange *Error like CheckRange.
+func CheckSaturationRange(fluidData *fluid.FluidData, T float64) *Error {
+	Tmin, Tmax := 0.0, math.Inf(1)
+	anc := fluidData.Ancillaries
+	for _, c := range []fluid.AncillaryCurve{anc.PS, anc.RhoL, anc.RhoV} {
+		if c.TMin > 0 {
+			Tmin = math.Max(Tmin, c.TMin)
+		}
+		if c.TMax > 0 {
+			Tmax = math.Min(Tmax, c.TMax)
+		}
+	}
+
+	return checkLimits(fluidData, []rangeLimit{
+		{"T_min_ancillary", T, Tmin, T < Tmin},
+		{"T_max_ancillary", T, Tmax, T > Tmax},
+	})
+}
+
+// rangeLimit is one validity limit of a variable and whether it is exceeded.
+type rangeLimit struct {
+	name         string
+	value, limit float64
+	exceeded     bool
+}
+
+// checkLimits returns an ErrOutOfRange *Error for the first exceeded limit
+// of a variable that is not NaN, or nil.
+func checkLimits(fluidData *fluid.FluidData, limits []rangeLimit) *Error {
+	for _, l := range limits {
+		if l.exceeded && !math.IsNaN(l.value) {
+			side := "above"
+			if l.value <= l.limit {
+				side = "below"
+			}
+			return &Error{
+				Kind:       ErrOutOfRange,
+				Fluid:      fluidData.Info.Name,
+				Detail:     fmt.Sprintf("%v is %s the validity limit %s=%v", l.value, side, l.name, l.limit),
+				Limit:      l.name,
+				LimitValue: l.limit,
+			}
+		}
+	}
+	return nil
+}
+
+// checkRange applies the range policy to T and P (NaN to skip) of a flash
+// with the given inputs. Metastable flashes accept negative pressures.
+func (o options) checkRange(fluidData *fluid.FluidData, op string, name1 string, val1 float64, name2 string, val2 float64, T, P float64) error {
+	if !o.rangeChecked || o.rangePolicy == RangeExtrapolate {
+		return nil
+	}
+	if o.metastable && P <= 0 {
+		P = math.NaN()
+	}
+	return o.applyRange(CheckRange(fluidData, T, P), op, name1, val1, name2, val2)
+}
+
+// checkSaturated applies the range policy to the temperature of a result
+// with quality Q, if it is a saturated mixture (Q >= 0).
+func (o options) checkSaturated(fluidData *fluid.FluidData, op string, name1 string, val1 float64, name2 string, val2 float64, T, Q float64) error {
+	if !o.rangeChecked || o.rangePolicy == RangeExtrapolate || Q < 0 {
+		return nil
+	}
+	return o.applyRange(CheckSaturationRange(fluidData, T), op, name1, val1, name2, val2)
+}
+
+// applyRange sets the operation and inputs of a range error e (which may be
+// nil) and applies the range policy to it.
+func (o options) applyRange(e *Error, op string, name1 string, val1 float64, name2 string, val2 float64) error {
+	if e == nil {
+		return nil
+	}
+	e.Op = op
+	e.Inputs = []Input{{name1, val1}, {name2, val2}}
+	return ApplyRangePolicy(o.rangePolicy, e)
+}
+
+// ApplyRangePolicy returns err under RangeError; under RangeWarn it passes
+// err to RangeWarning and returns nil, and under RangeExtrapolate it
+// returns nil.
+func ApplyRangePolicy(p RangePolicy, err error) error {
+	if err == nil {
+		return nil
+	}
+	switch p {
+	case RangeWarn:
+		RangeWarning(err)
+		return nil
+	case RangeExtrapolate:
+		return nil
+	}
+	return err
+}
+
+// singlePhasePressure returns the pressure of a single-phase result, or NaN
+// for a saturated mixture (Q >= 0), whose pressure is the saturation
+// pressure.
+func singlePhasePressure(fluidData *fluid.FluidData, T, Rho, Q float64) float64 {
+	if Q >= 0 {
+		return math.NaN()
+	}
+	state := core.NewState(fluidData)
+	state.Update(T, Rho)
+	return state.Pressure()
+}
@@ -0,0 +1,118 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/fluid"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCheckRange(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	cases := []struct {
+		T, P  float64
+		limit string
+	}{
+		{300, 101325, ""},
+		{250, 101325, "T_min"},
+		{5000, 101325, "T_max"},
+		{300, -1e5, "p_min"},
+		{300, 2e9, "p_max"},
+		{math.NaN(), 2e9, "p_max"},
+		{5000, math.NaN(), "T_max"},
+	}
+	for _, c := range cases {
+		e := CheckRange(f, c.T, c.P)
+		if c.limit == "" {
+			if e != nil {
+				t.Errorf("T=%v, P=%v: unexpected error %v", c.T, c.P, e)
+			}
+			continue
+		}
+		if e == nil || !errors.Is(e, ErrOutOfRange) || e.Limit != c.limit {
+			t.Errorf("T=%v, P=%v: expected limit %s, got %v", c.T, c.P, c.limit, e)
+		}
+	}
+}
+
+func TestCheckSaturationRange(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	// The saturation ancillaries of water span 273.16 K to 647.096 K
+	cases := []struct {
+		T     float64
+		limit string
+	}{
+		{373.15, ""},
+		{math.NaN(), ""},
+		{273.0, "T_min_ancillary"},
+		{650, "T_max_ancillary"},
+	}
+	for _, c := range cases {
+		e := CheckSaturationRange(f, c.T)
+		if c.limit == "" {
+			if e != nil {
+				t.Errorf("T=%v: unexpected error %v", c.T, e)
+			}
+			continue
+		}
+		if e == nil || !errors.Is(e, ErrOutOfRange) || e.Limit != c.limit {
+			t.Errorf("T=%v: expected limit %s, got %v", c.T, c.limit, e)
+		}
+	}
+}
+
+func TestFlashPH_RangePolicy(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	// Stretched liquid at negative pressure, unchecked by default
+	const P, H = -1e6, 2000.0
+	if _, _, _, err := FlashPH(f, P, H); err != nil {
+		t.Fatalf("FlashPH without a range policy failed: %v", err)
+	}
+
+	_, _, _, err = FlashPH(f, P, H, WithRangePolicy(RangeError))
+	var fe *Error
+	if !errors.Is(err, ErrOutOfRange) || !errors.As(err, &fe) {
+		t.Fatalf("Expected an ErrOutOfRange *Error, got %v", err)
+	}
+	if fe.Op != "FlashPH" || fe.Limit != "p_min" {
+		t.Errorf("Unexpected error fields: %+v", fe)
+	}
+
+	// Metastable flashes accept liquid under tension
+	if _, _, _, err := FlashPH(f, P, H, WithRangePolicy(RangeError), WithMetastable()); err != nil {
+		t.Errorf("Metastable FlashPH at negative pressure failed: %v", err)
+	}
+
+	// A warning is reported and the state is solved
+	defer func(w func(error)) { RangeWarning = w }(RangeWarning)
+	var warned error
+	RangeWarning = func(err error) { warned = err }
+	T, _, _, err := FlashPH(f, P, H, WithRangePolicy(RangeWarn))
+	if err != nil {
+		t.Fatalf("FlashPH with RangeWarn failed: %v", err)
+	}
+	if !errors.Is(warned, ErrOutOfRange) {
+		t.Errorf("Expected an ErrOutOfRange warning, got %v", warned)
+	}
+
+	warned = nil
+	T2, _, _, err := FlashPH(f, P, H, WithRangePolicy(RangeExtrapolate))
+	if err != nil {
+		t.Fatalf("FlashPH with RangeExtrapolate failed: %v", err)
+	}
+	if warned != nil || T2 != T {
+		t.Errorf("Extrapolated result T=%v (warning %v), expected T=%v without warning", T2, warned, T)
+	}
+}
@@ -0,0 +1,176 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/phase"
+	"GOcoolprop/pkg/saturation"
+	"math"
+)
+
+// Option configures a flash calculation.
+type Option func(*options)
+
+type options struct {
+	// Imposed phase; phase.Unknown lets the flash determine it
+	phase phase.Phase
+	// Return metastable single-phase roots instead of two-phase states
+	metastable bool
+	// Initial (T, Rho) tried before the flash's own guesses, if set
+	guess *[2]float64
+	// Incremented on every Newton iteration, if set
+	iterations *int
+	// Last Newton iteration, reported in errors
+	diag *diagnostics
+	// Handling of states outside the EOS validity range, if checked at all
+	rangePolicy  RangePolicy
+	rangeChecked bool
+}
+
+// WithPhase imposes the phase of the result. The flash then searches only
+// the matching density branch and skips the two-phase check, except for
+// phase.TwoPhase, which only accepts a saturated mixture.
+// phase.SupercriticalLiquid and phase.SupercriticalGas are treated as
+// phase.Liquid and phase.Gas.
+func WithPhase(p phase.Phase) Option {
+	return func(o *options) {
+		switch p {
+		case phase.SupercriticalLiquid:
+			p = phase.Liquid
+		case phase.SupercriticalGas:
+			p = phase.Gas
+		case phase.CriticalPoint:
+			p = phase.Supercritical
+		}
+		o.phase = p
+	}
+}
+
+// WithMetastable makes the flash return the metastable single-phase root
+// (superheated liquid or subcooled vapour) where it would otherwise return a
+// saturated mixture. The branch is the imposed phase if there is one, else
+// the saturated phase nearer in quality. Roots are accepted up to the
+// spinodal of that branch.
+func WithMetastable() Option {
+	return func(o *options) {
+		o.metastable = true
+	}
+}
+
+// WithGuess starts the single-phase Newton iteration from (T, Rho), such as
+// the result of a previous nearby flash, before falling back to the flash's
+// own initial guesses. It is used by FlashPH, FlashPS, FlashPU and FlashHS.
+func WithGuess(T, Rho float64) Option {
+	return func(o *options) {
+		o.guess = &[2]float64{T, Rho}
+	}
+}
+
+// withIterationCounter adds the number of Newton iterations to *n.
+func withIterationCounter(n *int) Option {
+	return func(o *options) {
+		o.iterations = n
+	}
+}
+
+func collectOptions(opts []Option) options {
+	o := options{diag: &diagnostics{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// checkTwoPhase reports whether the flash should test for a saturated
+// mixture before solving for a single-phase state.
+func (o options) checkTwoPhase() bool {
+	return o.phase == phase.Unknown || o.phase == phase.TwoPhase
+}
+
+// returnTwoPhase reports whether a target inside the dome gives the
+// saturated mixture. Otherwise the flash continues on the metastable branch
+// set by metastableBranch.
+func (o options) returnTwoPhase() bool {
+	return !o.metastable || o.phase == phase.TwoPhase
+}
+
+// metastableBranch imposes the branch for a metastable root inside the dome
+// with quality Q: liquid below Q = 0.5, gas above.
+func (o *options) metastableBranch(Q float64) {
+	if o.phase == phase.Unknown {
+		o.phase = phase.Gas
+		if Q < 0.5 {
+			o.phase = phase.Liquid
+		}
+	}
+}
+
+// checkDome reports whether single-phase roots inside the dome are rejected.
+func (o options) checkDome() bool {
+	return !o.metastable
+}
+
+// liquidBranch returns whether to start from the liquid side of the
+// saturation curve: the imposed phase if it is liquid or gas, otherwise
+// the flash's own estimate.
+func (o options) liquidBranch(estimate bool) bool {
+	switch o.phase {
+	case phase.Liquid:
+		return true
+	case phase.Gas:
+		return false
+	}
+	return estimate
+}
+
+// onBranch reports whether a single-phase (T, Rho) lies on the density
+// branch of the imposed phase. Below Tc the branches are bounded by the
+// saturated densities, or the spinodals for metastable roots, above Tc by
+// the critical density.
+func (o options) onBranch(fluidData *fluid.FluidData, T, Rho float64) bool {
+	switch o.phase {
+	case phase.Liquid:
+		rhoL, _ := o.branchBounds(fluidData, T)
+		return Rho >= rhoL
+	case phase.Gas:
+		_, rhoV := o.branchBounds(fluidData, T)
+		return Rho <= rhoV
+	case phase.TwoPhase:
+		return false
+	}
+	return true
+}
+
+// densityRange narrows the density search interval [rhoMin, rhoMax] at T to
+// the branch of the imposed phase.
+func (o options) densityRange(fluidData *fluid.FluidData, T, rhoMin, rhoMax float64) (float64, float64) {
+	switch o.phase {
+	case phase.Liquid:
+		rhoL, _ := o.branchBounds(fluidData, T)
+		rhoMin = math.Max(rhoMin, rhoL)
+	case phase.Gas:
+		_, rhoV := o.branchBounds(fluidData, T)
+		rhoMax = math.Min(rhoMax, rhoV)
+	}
+	return rhoMin, rhoMax
+}
+
+// branchBounds returns the lowest liquid and highest gas density at T: the
+// saturated densities (or spinodals, for metastable roots) below Tc and the
+// critical density above it.
+func (o options) branchBounds(fluidData *fluid.FluidData, T float64) (rhoL, rhoV float64) {
+	Rhoc := fluidData.States.Critical.RhoMolar
+	if T >= fluidData.States.Critical.T {
+		return Rhoc, Rhoc
+	}
+	if o.metastable {
+		if rhoVspin, rhoLspin, err := Spinodal(fluidData, T); err == nil {
+			return rhoLspin, rhoVspin
+		}
+	}
+	rhoL, errL := saturation.RhoL(fluidData, T)
+	rhoV, errV := saturation.RhoV(fluidData, T)
+	if errL != nil || errV != nil || rhoL <= 0 || rhoV <= 0 {
+		return Rhoc, Rhoc
+	}
+	return rhoL, rhoV
+}
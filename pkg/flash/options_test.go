@@ -0,0 +1,112 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/phase"
+	"GOcoolprop/pkg/saturation"
+	"math"
+	"testing"
+)
+
+func TestFlashPH_ImposedPhase(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	// Subcooled liquid at 350 K and 1 MPa
+	P_target := 1e6
+	T_expected := 350.0
+	state := core.NewState(f)
+	rhoL, _ := saturation.RhoL(f, T_expected)
+	state.Update(T_expected, rhoL)
+	H_target := state.MolarEnthalpy()
+
+	t.Logf("Test: P=%v Pa, H=%v J/mol", P_target, H_target)
+
+	T, Rho, Q, err := FlashPH(f, P_target, H_target, WithPhase(phase.Liquid))
+	if err != nil {
+		t.Fatalf("FlashPH with imposed liquid failed: %v", err)
+	}
+	t.Logf("Result: T=%v K, Rho=%v mol/m³, Q=%v", T, Rho, Q)
+
+	if Q != -1 || math.Abs(T-T_expected) > 1.0 {
+		t.Errorf("Expected liquid near %v K, got T=%v, Q=%v", T_expected, T, Q)
+	}
+
+	// The same state is not two-phase
+	if _, _, _, err := FlashPH(f, P_target, H_target, WithPhase(phase.TwoPhase)); err == nil {
+		t.Errorf("Expected an error when imposing two-phase on a liquid state")
+	}
+
+	// Imposing gas finds no vapour root with a liquid enthalpy
+	if T, Rho, _, err := FlashPH(f, P_target, H_target, WithPhase(phase.Gas)); err == nil {
+		t.Errorf("Expected an error when imposing gas, got T=%v, Rho=%v", T, Rho)
+	}
+}
+
+func TestFlashTH_ImposedPhase(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	T := 400.0
+	rhoL, _ := saturation.RhoL(f, T)
+	rhoV, _ := saturation.RhoV(f, T)
+
+	state := core.NewState(f)
+	state.Update(T, 0.5*rhoV)
+	H_gas := state.MolarEnthalpy()
+
+	rho, err := FlashTH(f, T, H_gas, WithPhase(phase.Gas))
+	if err != nil {
+		t.Fatalf("FlashTH with imposed gas failed: %v", err)
+	}
+	if math.Abs(rho-0.5*rhoV)/(0.5*rhoV) > 1e-6 {
+		t.Errorf("Gas density mismatch: got %v, expected %v", rho, 0.5*rhoV)
+	}
+
+	// Saturated mixture with Q = 0.3
+	state.Update(T, rhoL)
+	HL := state.MolarEnthalpy()
+	state.Update(T, rhoV)
+	HV := state.MolarEnthalpy()
+	Q := 0.3
+	rhoExpected := 1.0 / (Q/rhoV + (1-Q)/rhoL)
+
+	rho, err = FlashTH(f, T, HL+Q*(HV-HL), WithPhase(phase.TwoPhase))
+	if err != nil {
+		t.Fatalf("FlashTH with imposed two-phase failed: %v", err)
+	}
+	if math.Abs(rho-rhoExpected)/rhoExpected > 1e-9 {
+		t.Errorf("Mixture density mismatch: got %v, expected %v", rho, rhoExpected)
+	}
+}
+
+func TestFlashTU_ImposedPhase(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Nitrogen", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Nitrogen: %v", err)
+	}
+
+	// Compressed liquid at 100 K
+	T := 100.0
+	rhoL, _ := saturation.RhoL(f, T)
+	rhoExpected := 1.02 * rhoL
+
+	state := core.NewState(f)
+	state.Update(T, rhoExpected)
+	U_target := state.MolarInternalEnergy()
+
+	rho, Q, err := FlashTU(f, T, U_target, WithPhase(phase.Liquid))
+	if err != nil {
+		t.Fatalf("FlashTU with imposed liquid failed: %v", err)
+	}
+	t.Logf("Result: rho=%v mol/m³, Q=%v", rho, Q)
+
+	if Q != -1 || math.Abs(rho-rhoExpected)/rhoExpected > 1e-6 {
+		t.Errorf("Liquid density mismatch: got %v (Q=%v), expected %v", rho, Q, rhoExpected)
+	}
+}
@@ -3,13 +3,34 @@ package flash
 import (
 	"GOcoolprop/pkg/core"
 	"GOcoolprop/pkg/fluid"
-	"GOcoolprop/pkg/solver"
-	"fmt"
+	"GOcoolprop/pkg/phase"
+	"GOcoolprop/pkg/saturation"
+	"math"
 )
 
 // FlashPH solves for Temperature and Density given Pressure and Enthalpy.
-// Returns T (K) and Rho (mol/m³).
-func FlashPH(fluidData *fluid.FluidData, P_target, H_target float64) (float64, float64, error) {
+// Returns T (K), Rho (mol/m³) and the vapour quality Q.
+// Q is -1 for single-phase states. See WithPhase for imposing the phase.
+func FlashPH(fluidData *fluid.FluidData, P_target, H_target float64, opts ...Option) (float64, float64, float64, error) {
+	o := collectOptions(opts)
+	if err := o.checkRange(fluidData, "FlashPH", "P", P_target, "H", H_target, math.NaN(), P_target); err != nil {
+		return 0, 0, 0, err
+	}
+	T, Rho, Q, err := flashPH(fluidData, P_target, H_target, o)
+	if err == nil {
+		err = o.checkRange(fluidData, "FlashPH", "P", P_target, "H", H_target, T, math.NaN())
+	}
+	if err == nil {
+		err = o.checkSaturated(fluidData, "FlashPH", "P", P_target, "H", H_target, T, Q)
+	}
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return T, Rho, Q, nil
+}
+
+// flashPH is FlashPH without the range checks.
+func flashPH(fluidData *fluid.FluidData, P_target, H_target float64, o options) (float64, float64, float64, error) {
 	state := core.NewState(fluidData)
 
 	// Define the system of equations and Jacobian
@@ -23,16 +44,87 @@ func FlashPH(fluidData *fluid.FluidData, P_target, H_target float64) (float64, f
 		// Jacobian elements
 		J11 = state.DPdT()
 		J12 = state.DPdRho()
-		J21 = state.DHdT() // Cp
+		J21 = state.DHdT()
 		J22 = state.DHdRho()
 
 		return
 	}
 
-	// Initial Guess Strategy
-	// 1. Assume ideal gas to get initial T and Rho
 	R := fluidData.EOS[0].GasConstant
+	Tmin, Tmax := saturationRange(fluidData)
+	guesses := make([][2]float64, 0, 4)
+
+	// ---- Two-phase check against the saturated states at P ----
+	// Between hL and hV the single-phase EOS only has metastable or unstable
+	// roots, so the state is a mixture at Tsat.
+	// An imposed single phase skips the check and starts from the branch
+	// guesses below.
+	subcritical := false
+	if Tsat, err := saturation.Tsat(fluidData, P_target); err == nil && o.checkTwoPhase() {
+		if sat, err := saturatedStates(state, Tsat); err == nil {
+			subcritical = true
+			liquid := H_target < sat.HL
+			if H_target >= sat.HL && H_target <= sat.HV {
+				Q := (H_target - sat.HL) / (sat.HV - sat.HL)
+				if o.returnTwoPhase() {
+					return Tsat, mixtureDensity(sat.RhoL, sat.RhoV, Q), Q, nil
+				}
+				// Metastable root: continue from the saturated phase
+				o.metastableBranch(Q)
+				liquid = o.phase == phase.Liquid
+			}
+
+			// Single phase: step away from the saturated phase with Cp
+			if liquid {
+				state.Update(Tsat, sat.RhoL)
+				T0 := math.Max(Tsat-(sat.HL-H_target)/state.Cp(), Tmin)
+				guesses = append(guesses, [2]float64{T0, sat.RhoL})
+			} else {
+				state.Update(Tsat, sat.RhoV)
+				T0 := Tsat + (H_target-sat.HV)/state.Cp()
+				guesses = append(guesses, [2]float64{T0, P_target / (R * T0)})
+			}
+		}
+	}
+
+	if o.phase == phase.TwoPhase {
+		return 0, 0, 0, newError(ErrOutOfRange, "FlashPH", fluidData, "P", P_target, "H", H_target, "not a two-phase state")
+	}
+
+	// A stable state at positive pressure never lies inside the dome;
+	// negative pressures are only reached by liquid under tension.
+	checkDome := P_target > 0 && o.checkDome()
+
+	// ---- Warm start from a previous solution ----
+	if o.guess != nil {
+		if T, Rho, ok := o.newtonRoot(fluidData, funcJS, *o.guess, checkDome); ok {
+			return T, Rho, -1, nil
+		}
+	}
+
+	// ---- Supercritical or out-of-range pressure ----
+	// Start from the saturated state with the target enthalpy on the liquid or
+	// vapour branch, split at the critical point unless the phase is imposed.
+	if !subcritical {
+		Tc := fluidData.States.Critical.T
+		state.Update(Tc, fluidData.States.Critical.RhoMolar)
+		liquid := o.liquidBranch(H_target < state.MolarEnthalpy())
+		branch := func(sat satStates) float64 { return sat.HV }
+		if liquid {
+			branch = func(sat satStates) float64 { return sat.HL }
+		}
+		Tstar := branchTemperature(state, branch, H_target, Tmin, Tmax)
+		if sat, err := saturatedStates(state, Tstar); err == nil && liquid {
+			guesses = append(guesses, [2]float64{Tstar, sat.RhoL})
+		} else if err == nil {
+			// At constant enthalpy an ideal gas keeps its temperature
+			guesses = append(guesses, [2]float64{Tstar, P_target / (R * Tstar)})
+		}
+		guesses = append(guesses, [2]float64{1.2 * Tc, fluidData.States.Critical.RhoMolar})
+	}
 
+	// ---- Generic fallbacks ----
+	// 1. Assume ideal gas to get initial T and Rho
 	// Rough guess for T based on H (assuming ideal gas with constant Cp ~ 2.5R or 3.5R)
 	// H = Cp*T => T = H/Cp
 	// For noble gases Cp=2.5R, for diatomics Cp=3.5R. Let's take 4R as a safe average.
@@ -41,33 +133,21 @@ func FlashPH(fluidData *fluid.FluidData, P_target, H_target float64) (float64, f
 	if T_guess < fluidData.States.TripleLiquid.T {
 		T_guess = fluidData.States.TripleLiquid.T * 1.1
 	}
+	guesses = append(guesses, [2]float64{T_guess, P_target / (R * T_guess)})
 
-	Rho_guess := P_target / (R * T_guess)
-
-	// Refine guess if we are likely liquid
-	// If P is high and H is low, we might be liquid.
-	// But for now, let's try the Newton solver with this guess.
-	// If it fails or diverges, we might need a better guess or a 1D fallback (iterate T, solve rho(P,T), check H).
-
-	// Try 2D Newton
-	T, Rho, err := solver.Newton2D(funcJS, T_guess, Rho_guess, 1e-6, 100)
-	if err == nil && T > 0 && Rho > 0 {
-		return T, Rho, nil
-	}
-
-	// If failed, try a liquid-like guess
+	// 2. Liquid-like guess
 	// Liquid is incompressible-ish, so Rho ~ Rho_triple_liquid
-	Rho_guess = fluidData.States.TripleLiquid.RhoMolar
+	Rho_guess := fluidData.States.TripleLiquid.RhoMolar
 	if Rho_guess == 0 {
 		Rho_guess = fluidData.States.Critical.RhoMolar * 2.5
 	}
-	// T guess? Maybe saturation T at P? Or just standard T.
-	T_guess = 300.0
+	guesses = append(guesses, [2]float64{300.0, Rho_guess})
 
-	T, Rho, err = solver.Newton2D(funcJS, T_guess, Rho_guess, 1e-6, 100)
-	if err == nil && T > 0 && Rho > 0 {
-		return T, Rho, nil
+	for _, guess := range guesses {
+		if T, Rho, ok := o.newtonRoot(fluidData, funcJS, guess, checkDome); ok {
+			return T, Rho, -1, nil
+		}
 	}
 
-	return 0, 0, fmt.Errorf("FlashPH failed for P=%v, H=%v", P_target, H_target)
+	return 0, 0, 0, o.noConvergence("FlashPH", fluidData, "P", P_target, "H", H_target)
 }
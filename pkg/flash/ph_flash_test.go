@@ -3,6 +3,7 @@ package flash
 import (
 	"GOcoolprop/pkg/core"
 	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/saturation"
 	"math"
 	"testing"
 )
@@ -31,7 +32,7 @@ func TestFlashPH_Nitrogen_Gas(t *testing.T) {
 	t.Logf("Target: P=%v Pa, H=%v J/mol (at T=%v, rho=%v)", P_actual, H_target, T_expected, rho_setup)
 
 	// FlashPH
-	T_calc, Rho_calc, err := FlashPH(f, P_actual, H_target)
+	T_calc, Rho_calc, _, err := FlashPH(f, P_actual, H_target)
 	if err != nil {
 		t.Fatalf("FlashPH failed: %v", err)
 	}
@@ -65,7 +66,7 @@ func TestFlashPH_Water_Liquid(t *testing.T) {
 	t.Logf("Target: P=%v Pa, H=%v J/mol (at T=%v, rho=%v)", P_actual, H_target, T_expected, rho_setup)
 
 	// FlashPH
-	T_calc, Rho_calc, err := FlashPH(f, P_actual, H_target)
+	T_calc, Rho_calc, _, err := FlashPH(f, P_actual, H_target)
 	if err != nil {
 		t.Fatalf("FlashPH failed: %v", err)
 	}
@@ -80,3 +81,52 @@ func TestFlashPH_Water_Liquid(t *testing.T) {
 		t.Errorf("Density mismatch: got %v, expected %v", Rho_calc, rho_setup)
 	}
 }
+
+func TestFlashPH_Water_TwoPhase(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	// Target state: wet steam at 1 MPa with 40% quality
+	P_target := 1.0e6
+	Q_expected := 0.4
+
+	T_expected, err := saturation.Tsat(f, P_target)
+	if err != nil {
+		t.Fatalf("Tsat failed: %v", err)
+	}
+	rhoL, _ := saturation.RhoL(f, T_expected)
+	rhoV, _ := saturation.RhoV(f, T_expected)
+
+	state := core.NewState(f)
+	state.Update(T_expected, rhoL)
+	HL := state.MolarEnthalpy()
+	state.Update(T_expected, rhoV)
+	HV := state.MolarEnthalpy()
+
+	H_target := HL + Q_expected*(HV-HL)
+
+	t.Logf("Target: P=%v Pa, H=%v J/mol (Tsat=%v, Q=%v)", P_target, H_target, T_expected, Q_expected)
+
+	// FlashPH
+	T_calc, Rho_calc, Q_calc, err := FlashPH(f, P_target, H_target)
+	if err != nil {
+		t.Fatalf("FlashPH failed: %v", err)
+	}
+
+	t.Logf("Result: T=%v K, Rho=%v mol/m³, Q=%v", T_calc, Rho_calc, Q_calc)
+
+	if math.Abs(T_calc-T_expected) > 1e-6 {
+		t.Errorf("Temperature mismatch: got %v, expected %v", T_calc, T_expected)
+	}
+
+	if math.Abs(Q_calc-Q_expected) > 1e-9 {
+		t.Errorf("Quality mismatch: got %v, expected %v", Q_calc, Q_expected)
+	}
+
+	rho_expected := 1.0 / (Q_expected/rhoV + (1-Q_expected)/rhoL)
+	if math.Abs(Rho_calc-rho_expected)/rho_expected > 1e-9 {
+		t.Errorf("Density mismatch: got %v, expected %v", Rho_calc, rho_expected)
+	}
+}
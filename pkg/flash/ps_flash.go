@@ -3,13 +3,34 @@ package flash
 import (
 	"GOcoolprop/pkg/core"
 	"GOcoolprop/pkg/fluid"
-	"GOcoolprop/pkg/solver"
-	"fmt"
+	"GOcoolprop/pkg/phase"
+	"GOcoolprop/pkg/saturation"
+	"math"
 )
 
 // FlashPS solves for Temperature and Density given Pressure and Entropy.
-// Returns T (K) and Rho (mol/m³).
-func FlashPS(fluidData *fluid.FluidData, P_target, S_target float64) (float64, float64, error) {
+// Returns T (K), Rho (mol/m³) and the vapour quality Q.
+// Q is -1 for single-phase states. See WithPhase for imposing the phase.
+func FlashPS(fluidData *fluid.FluidData, P_target, S_target float64, opts ...Option) (float64, float64, float64, error) {
+	o := collectOptions(opts)
+	if err := o.checkRange(fluidData, "FlashPS", "P", P_target, "S", S_target, math.NaN(), P_target); err != nil {
+		return 0, 0, 0, err
+	}
+	T, Rho, Q, err := flashPS(fluidData, P_target, S_target, o)
+	if err == nil {
+		err = o.checkRange(fluidData, "FlashPS", "P", P_target, "S", S_target, T, math.NaN())
+	}
+	if err == nil {
+		err = o.checkSaturated(fluidData, "FlashPS", "P", P_target, "S", S_target, T, Q)
+	}
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return T, Rho, Q, nil
+}
+
+// flashPS is FlashPS without the range checks.
+func flashPS(fluidData *fluid.FluidData, P_target, S_target float64, o options) (float64, float64, float64, error) {
 	state := core.NewState(fluidData)
 
 	// Define the system of equations and Jacobian
@@ -29,34 +50,103 @@ func FlashPS(fluidData *fluid.FluidData, P_target, S_target float64) (float64, f
 		return
 	}
 
-	// Initial Guess Strategy
-	// 1. Assume ideal gas to get initial T and Rho
 	R := fluidData.EOS[0].GasConstant
+	Tmin, Tmax := saturationRange(fluidData)
+	guesses := make([][2]float64, 0, 4)
+
+	// ---- Two-phase check against the saturated states at P ----
+	// Between sL and sV the single-phase EOS only has metastable or unstable
+	// roots, so the state is a mixture at Tsat.
+	// An imposed single phase skips the check and starts from the branch
+	// guesses below.
+	subcritical := false
+	if Tsat, err := saturation.Tsat(fluidData, P_target); err == nil && o.checkTwoPhase() {
+		if sat, err := saturatedStates(state, Tsat); err == nil {
+			subcritical = true
+			liquid := S_target < sat.SL
+			if S_target >= sat.SL && S_target <= sat.SV {
+				Q := (S_target - sat.SL) / (sat.SV - sat.SL)
+				if o.returnTwoPhase() {
+					return Tsat, mixtureDensity(sat.RhoL, sat.RhoV, Q), Q, nil
+				}
+				// Metastable root: continue from the saturated phase
+				o.metastableBranch(Q)
+				liquid = o.phase == phase.Liquid
+			}
+
+			// Single phase: step away from the saturated phase along the
+			// isobar, where dS = Cp·dT/T
+			if liquid {
+				state.Update(Tsat, sat.RhoL)
+				T0 := math.Max(Tsat*math.Exp(-(sat.SL-S_target)/state.Cp()), Tmin)
+				guesses = append(guesses, [2]float64{T0, sat.RhoL})
+			} else {
+				state.Update(Tsat, sat.RhoV)
+				T0 := Tsat * math.Exp((S_target-sat.SV)/state.Cp())
+				guesses = append(guesses, [2]float64{T0, P_target / (R * T0)})
+			}
+		}
+	}
 
+	if o.phase == phase.TwoPhase {
+		return 0, 0, 0, newError(ErrOutOfRange, "FlashPS", fluidData, "P", P_target, "S", S_target, "not a two-phase state")
+	}
+
+	// A stable state at positive pressure never lies inside the dome;
+	// negative pressures are only reached by liquid under tension.
+	checkDome := P_target > 0 && o.checkDome()
+
+	// ---- Warm start from a previous solution ----
+	if o.guess != nil {
+		if T, Rho, ok := o.newtonRoot(fluidData, funcJS, *o.guess, checkDome); ok {
+			return T, Rho, -1, nil
+		}
+	}
+
+	// ---- Supercritical or out-of-range pressure ----
+	// Start from the saturated state with the target entropy on the liquid or
+	// vapour branch, split at the critical point unless the phase is imposed.
+	if !subcritical {
+		Tc := fluidData.States.Critical.T
+		state.Update(Tc, fluidData.States.Critical.RhoMolar)
+		liquid := o.liquidBranch(S_target < state.MolarEntropy())
+		branch := func(sat satStates) float64 { return sat.SV }
+		if liquid {
+			branch = func(sat satStates) float64 { return sat.SL }
+		}
+		Tstar := branchTemperature(state, branch, S_target, Tmin, Tmax)
+		if sat, err := saturatedStates(state, Tstar); err == nil && liquid {
+			guesses = append(guesses, [2]float64{Tstar, sat.RhoL})
+		} else if err == nil {
+			// Move to P as an ideal gas: S - S* = Cp·ln(T/T*) - R·ln(P/P*)
+			state.Update(Tstar, sat.RhoV)
+			T0 := Tstar * math.Exp((S_target-sat.SV+R*math.Log(P_target/state.Pressure()))/state.Cp())
+			guesses = append(guesses, [2]float64{T0, P_target / (R * T0)})
+		}
+		guesses = append(guesses, [2]float64{1.2 * Tc, fluidData.States.Critical.RhoMolar})
+	}
+
+	// ---- Generic fallbacks ----
+	// 1. Assume ideal gas to get initial T and Rho
 	// Rough guess for T based on S (assuming ideal gas)
 	// S = S0 + Cp*ln(T/T0) - R*ln(P/P0)
 	// This is hard to invert without reference state.
 	// Let's use a standard guess T=300K and refine from there.
 	T_guess := 300.0
-	Rho_guess := P_target / (R * T_guess)
-
-	// Try 2D Newton
-	T, Rho, err := solver.Newton2D(funcJS, T_guess, Rho_guess, 1e-6, 100)
-	if err == nil && T > 0 && Rho > 0 {
-		return T, Rho, nil
-	}
+	guesses = append(guesses, [2]float64{T_guess, P_target / (R * T_guess)})
 
-	// If failed, try a liquid-like guess
-	Rho_guess = fluidData.States.TripleLiquid.RhoMolar
+	// 2. Liquid-like guess
+	Rho_guess := fluidData.States.TripleLiquid.RhoMolar
 	if Rho_guess == 0 {
 		Rho_guess = fluidData.States.Critical.RhoMolar * 2.5
 	}
-	T_guess = 300.0
+	guesses = append(guesses, [2]float64{300.0, Rho_guess})
 
-	T, Rho, err = solver.Newton2D(funcJS, T_guess, Rho_guess, 1e-6, 100)
-	if err == nil && T > 0 && Rho > 0 {
-		return T, Rho, nil
+	for _, guess := range guesses {
+		if T, Rho, ok := o.newtonRoot(fluidData, funcJS, guess, checkDome); ok {
+			return T, Rho, -1, nil
+		}
 	}
 
-	return 0, 0, fmt.Errorf("FlashPS failed for P=%v, S=%v", P_target, S_target)
+	return 0, 0, 0, o.noConvergence("FlashPS", fluidData, "P", P_target, "S", S_target)
 }
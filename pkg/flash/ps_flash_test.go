@@ -3,6 +3,7 @@ package flash
 import (
 	"GOcoolprop/pkg/core"
 	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/saturation"
 	"math"
 	"testing"
 )
@@ -25,7 +26,7 @@ func TestFlashPS_Nitrogen_Gas(t *testing.T) {
 	t.Logf("Target: P=%v Pa, S=%v J/mol/K (at T=%v, rho=%v)", P_actual, S_target, T_expected, rho_setup)
 
 	// FlashPS
-	T_calc, Rho_calc, err := FlashPS(f, P_actual, S_target)
+	T_calc, Rho_calc, _, err := FlashPS(f, P_actual, S_target)
 	if err != nil {
 		t.Fatalf("FlashPS failed: %v", err)
 	}
@@ -59,7 +60,7 @@ func TestFlashPS_Water_Liquid(t *testing.T) {
 	t.Logf("Target: P=%v Pa, S=%v J/mol/K (at T=%v, rho=%v)", P_actual, S_target, T_expected, rho_setup)
 
 	// FlashPS
-	T_calc, Rho_calc, err := FlashPS(f, P_actual, S_target)
+	T_calc, Rho_calc, _, err := FlashPS(f, P_actual, S_target)
 	if err != nil {
 		t.Fatalf("FlashPS failed: %v", err)
 	}
@@ -74,3 +75,47 @@ func TestFlashPS_Water_Liquid(t *testing.T) {
 		t.Errorf("Density mismatch: got %v, expected %v", Rho_calc, rho_setup)
 	}
 }
+
+func TestFlashPS_Nitrogen_TwoPhase(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Nitrogen", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Nitrogen: %v", err)
+	}
+
+	// Target state: boiling nitrogen at 1 atm with 70% quality
+	P_target := 101325.0
+	Q_expected := 0.7
+
+	T_expected, err := saturation.Tsat(f, P_target)
+	if err != nil {
+		t.Fatalf("Tsat failed: %v", err)
+	}
+	rhoL, _ := saturation.RhoL(f, T_expected)
+	rhoV, _ := saturation.RhoV(f, T_expected)
+
+	state := core.NewState(f)
+	state.Update(T_expected, rhoL)
+	SL := state.MolarEntropy()
+	state.Update(T_expected, rhoV)
+	SV := state.MolarEntropy()
+
+	S_target := SL + Q_expected*(SV-SL)
+
+	t.Logf("Target: P=%v Pa, S=%v J/mol/K (Tsat=%v, Q=%v)", P_target, S_target, T_expected, Q_expected)
+
+	// FlashPS
+	T_calc, Rho_calc, Q_calc, err := FlashPS(f, P_target, S_target)
+	if err != nil {
+		t.Fatalf("FlashPS failed: %v", err)
+	}
+
+	t.Logf("Result: T=%v K, Rho=%v mol/m³, Q=%v", T_calc, Rho_calc, Q_calc)
+
+	if math.Abs(T_calc-T_expected) > 1e-6 {
+		t.Errorf("Temperature mismatch: got %v, expected %v", T_calc, T_expected)
+	}
+
+	if math.Abs(Q_calc-Q_expected) > 1e-9 {
+		t.Errorf("Quality mismatch: got %v, expected %v", Q_calc, Q_expected)
+	}
+}
@@ -0,0 +1,129 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/phase"
+	"GOcoolprop/pkg/saturation"
+	"math"
+)
+
+// FlashPU solves for Temperature and Density given Pressure and Internal Energy.
+// Returns T (K), Rho (mol/m³) and the vapour quality Q.
+// Q is -1 for single-phase states. See WithPhase for imposing the phase.
+func FlashPU(fluidData *fluid.FluidData, P_target, U_target float64, opts ...Option) (float64, float64, float64, error) {
+	o := collectOptions(opts)
+	if err := o.checkRange(fluidData, "FlashPU", "P", P_target, "U", U_target, math.NaN(), P_target); err != nil {
+		return 0, 0, 0, err
+	}
+	T, Rho, Q, err := flashPU(fluidData, P_target, U_target, o)
+	if err == nil {
+		err = o.checkRange(fluidData, "FlashPU", "P", P_target, "U", U_target, T, math.NaN())
+	}
+	if err == nil {
+		err = o.checkSaturated(fluidData, "FlashPU", "P", P_target, "U", U_target, T, Q)
+	}
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return T, Rho, Q, nil
+}
+
+// flashPU is FlashPU without the range checks.
+func flashPU(fluidData *fluid.FluidData, P_target, U_target float64, o options) (float64, float64, float64, error) {
+	state := core.NewState(fluidData)
+
+	// Define the system of equations and Jacobian
+	funcJS := func(T, Rho float64) (f1, f2, J11, J12, J21, J22 float64) {
+		state.Update(T, Rho)
+
+		// Residuals
+		f1 = state.Pressure() - P_target
+		f2 = state.MolarInternalEnergy() - U_target
+
+		// Jacobian elements
+		J11 = state.DPdT()
+		J12 = state.DPdRho()
+		J21 = state.DUdT() // Cv
+		J22 = state.DUdRho()
+
+		return
+	}
+
+	R := fluidData.EOS[0].GasConstant
+	Tmin, Tmax := saturationRange(fluidData)
+	guesses := make([][2]float64, 0, 4)
+
+	// ---- Two-phase check against the saturated states at P ----
+	// An imposed single phase skips the check.
+	if Tsat, err := saturation.Tsat(fluidData, P_target); err == nil && o.checkTwoPhase() {
+		if sat, err := saturatedStates(state, Tsat); err == nil {
+			liquid := U_target < sat.UL
+			if U_target >= sat.UL && U_target <= sat.UV {
+				Q := (U_target - sat.UL) / (sat.UV - sat.UL)
+				if o.returnTwoPhase() {
+					return Tsat, mixtureDensity(sat.RhoL, sat.RhoV, Q), Q, nil
+				}
+				// Metastable root: continue from the saturated phase
+				o.metastableBranch(Q)
+				liquid = o.phase == phase.Liquid
+			}
+
+			// Single phase: step away from the saturated phase with Cv
+			if liquid {
+				state.Update(Tsat, sat.RhoL)
+				T0 := math.Max(Tsat-(sat.UL-U_target)/state.Cv(), Tmin)
+				guesses = append(guesses, [2]float64{T0, sat.RhoL})
+			} else {
+				state.Update(Tsat, sat.RhoV)
+				T0 := Tsat + (U_target-sat.UV)/state.Cv()
+				guesses = append(guesses, [2]float64{T0, P_target / (R * T0)})
+			}
+		}
+	}
+
+	if o.phase == phase.TwoPhase {
+		return 0, 0, 0, newError(ErrOutOfRange, "FlashPU", fluidData, "P", P_target, "U", U_target, "not a two-phase state")
+	}
+
+	// ---- Warm start from a previous solution ----
+	if o.guess != nil {
+		if T, Rho, ok := o.newtonRoot(fluidData, funcJS, *o.guess, o.checkDome()); ok {
+			return T, Rho, -1, nil
+		}
+	}
+
+	// ---- Supercritical or out-of-range pressure ----
+	// Start from the saturated state with the target internal energy on the
+	// liquid or vapour branch, split at the critical point unless the phase
+	// is imposed.
+	Tc := fluidData.States.Critical.T
+	Rhoc := fluidData.States.Critical.RhoMolar
+
+	state.Update(Tc, Rhoc)
+	if o.liquidBranch(U_target < state.MolarInternalEnergy()) {
+		Tstar := branchTemperature(state, func(sat satStates) float64 { return sat.UL }, U_target, Tmin, Tmax)
+		if sat, err := saturatedStates(state, Tstar); err == nil {
+			guesses = append(guesses, [2]float64{Tstar, sat.RhoL})
+		}
+	}
+
+	// Step from the dilute gas at Tc with its (nearly ideal-gas) Cv, then try
+	// gas-like, critical and liquid-like densities at that temperature.
+	state.Update(Tc, 1e-3*Rhoc)
+	T0 := math.Max(Tc+(U_target-state.MolarInternalEnergy())/state.Cv(), Tmin)
+	guesses = append(guesses,
+		[2]float64{T0, P_target / (R * T0)},
+		[2]float64{T0, Rhoc},
+		[2]float64{T0, 2.5 * Rhoc},
+		[2]float64{1.2 * Tc, Rhoc},
+	)
+
+	for _, guess := range guesses {
+		if T, Rho, ok := o.newtonRoot(fluidData, funcJS, guess, o.checkDome()); ok {
+			return T, Rho, -1, nil
+		}
+	}
+
+	return 0, 0, 0, o.noConvergence("FlashPU", fluidData, "P", P_target, "U", U_target)
+}
@@ -0,0 +1,123 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/saturation"
+	"math"
+	"testing"
+)
+
+func TestFlashPU_Nitrogen_Gas(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Nitrogen", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Nitrogen: %v", err)
+	}
+
+	// Target state: 300K, 1 atm
+	T_expected := 300.0
+	rho_setup := 40.6
+
+	state := core.NewState(f)
+	state.Update(T_expected, rho_setup)
+	P_actual := state.Pressure()
+	U_target := state.MolarInternalEnergy()
+
+	t.Logf("Target: P=%v Pa, U=%v J/mol (at T=%v, rho=%v)", P_actual, U_target, T_expected, rho_setup)
+
+	// FlashPU
+	T_calc, Rho_calc, Q_calc, err := FlashPU(f, P_actual, U_target)
+	if err != nil {
+		t.Fatalf("FlashPU failed: %v", err)
+	}
+
+	t.Logf("Result: T=%v K, Rho=%v mol/m³, Q=%v", T_calc, Rho_calc, Q_calc)
+
+	if math.Abs(T_calc-T_expected) > 0.1 {
+		t.Errorf("Temperature mismatch: got %v, expected %v", T_calc, T_expected)
+	}
+
+	if math.Abs(Rho_calc-rho_setup) > 0.1 {
+		t.Errorf("Density mismatch: got %v, expected %v", Rho_calc, rho_setup)
+	}
+
+	if Q_calc != -1 {
+		t.Errorf("Expected single-phase quality -1, got %v", Q_calc)
+	}
+}
+
+func TestFlashPU_Water_Liquid(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	// Target state: 300K, compressed liquid
+	T_expected := 300.0
+	rho_setup := 55500.0
+
+	state := core.NewState(f)
+	state.Update(T_expected, rho_setup)
+	P_actual := state.Pressure()
+	U_target := state.MolarInternalEnergy()
+
+	t.Logf("Target: P=%v Pa, U=%v J/mol (at T=%v, rho=%v)", P_actual, U_target, T_expected, rho_setup)
+
+	// FlashPU
+	T_calc, Rho_calc, _, err := FlashPU(f, P_actual, U_target)
+	if err != nil {
+		t.Fatalf("FlashPU failed: %v", err)
+	}
+
+	t.Logf("Result: T=%v K, Rho=%v mol/m³", T_calc, Rho_calc)
+
+	if math.Abs(T_calc-T_expected) > 0.1 {
+		t.Errorf("Temperature mismatch: got %v, expected %v", T_calc, T_expected)
+	}
+
+	if math.Abs(Rho_calc-rho_setup) > 100.0 {
+		t.Errorf("Density mismatch: got %v, expected %v", Rho_calc, rho_setup)
+	}
+}
+
+func TestFlashPU_Water_TwoPhase(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	// Target state: wet steam at 1 atm with 60% quality
+	P_target := 101325.0
+	Q_expected := 0.6
+
+	T_expected, err := saturation.Tsat(f, P_target)
+	if err != nil {
+		t.Fatalf("Tsat failed: %v", err)
+	}
+	rhoL, _ := saturation.RhoL(f, T_expected)
+	rhoV, _ := saturation.RhoV(f, T_expected)
+
+	state := core.NewState(f)
+	state.Update(T_expected, rhoL)
+	UL := state.MolarInternalEnergy()
+	state.Update(T_expected, rhoV)
+	UV := state.MolarInternalEnergy()
+
+	U_target := UL + Q_expected*(UV-UL)
+
+	// FlashPU
+	T_calc, Rho_calc, Q_calc, err := FlashPU(f, P_target, U_target)
+	if err != nil {
+		t.Fatalf("FlashPU failed: %v", err)
+	}
+
+	t.Logf("Result: T=%v K, Rho=%v mol/m³, Q=%v", T_calc, Rho_calc, Q_calc)
+
+	if math.Abs(T_calc-T_expected) > 1e-6 {
+		t.Errorf("Temperature mismatch: got %v, expected %v", T_calc, T_expected)
+	}
+
+	if math.Abs(Q_calc-Q_expected) > 1e-9 {
+		t.Errorf("Quality mismatch: got %v, expected %v", Q_calc, Q_expected)
+	}
+}
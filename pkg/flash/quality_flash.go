@@ -0,0 +1,94 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/solver"
+	"fmt"
+	"math"
+)
+
+// FlashHQ solves for the saturation temperature given molar enthalpy and
+// vapour quality. Returns T (K) and the mixture density Rho (mol/m³).
+func FlashHQ(fluidData *fluid.FluidData, H_target, Q float64) (float64, float64, error) {
+	return qualityFlash(fluidData, "H", H_target, H_target, Q, func(sat satStates) float64 {
+		return (1-Q)*sat.HL + Q*sat.HV
+	})
+}
+
+// FlashSQ solves for the saturation temperature given molar entropy and
+// vapour quality. Returns T (K) and the mixture density Rho (mol/m³).
+func FlashSQ(fluidData *fluid.FluidData, S_target, Q float64) (float64, float64, error) {
+	return qualityFlash(fluidData, "S", S_target, S_target, Q, func(sat satStates) float64 {
+		return (1-Q)*sat.SL + Q*sat.SV
+	})
+}
+
+// FlashUQ solves for the saturation temperature given molar internal energy
+// and vapour quality. Returns T (K) and the mixture density Rho (mol/m³).
+func FlashUQ(fluidData *fluid.FluidData, U_target, Q float64) (float64, float64, error) {
+	return qualityFlash(fluidData, "U", U_target, U_target, Q, func(sat satStates) float64 {
+		return (1-Q)*sat.UL + Q*sat.UV
+	})
+}
+
+// FlashDQ solves for the saturation temperature given the mixture molar
+// density and vapour quality. Returns T (K) and Rho (mol/m³).
+func FlashDQ(fluidData *fluid.FluidData, Rho_target, Q float64) (float64, float64, error) {
+	if Rho_target <= 0 {
+		return 0, 0, newError(ErrOutOfRange, "FlashDQ", fluidData, "D", Rho_target, "Q", Q, "density must be positive")
+	}
+	// Specific volumes are linear in quality
+	return qualityFlash(fluidData, "D", Rho_target, 1.0/Rho_target, Q, func(sat satStates) float64 {
+		return 1.0 / mixtureDensity(sat.RhoL, sat.RhoV, Q)
+	})
+}
+
+// qualityFlash finds the saturation temperature at which prop, evaluated on
+// the saturated states, equals target; input is the value reported in
+// errors. The saturation range is scanned for sign changes, since the
+// saturated vapour enthalpy and the entropy of some fluids pass through a
+// maximum; when several temperatures match, the lowest one is returned.
+func qualityFlash(fluidData *fluid.FluidData, name string, input, target, Q float64, prop func(satStates) float64) (float64, float64, error) {
+	if Q < 0 || Q > 1 || math.IsNaN(Q) {
+		return 0, 0, newError(ErrOutOfRange, "Flash"+name+"Q", fluidData, name, input, "Q", Q, "quality outside [0, 1]")
+	}
+
+	state := core.NewState(fluidData)
+	obj := func(T float64) float64 {
+		sat, err := saturatedStates(state, T)
+		if err != nil {
+			return math.NaN()
+		}
+		return prop(sat) - target
+	}
+
+	Tmin, Tmax := saturationRange(fluidData)
+
+	const nScan = 100
+	dT := (Tmax - Tmin) / float64(nScan)
+	prevT := Tmin
+	prevVal := obj(prevT)
+	for i := 1; i <= nScan; i++ {
+		T := Tmin + dT*float64(i)
+		if i == nScan {
+			// Stay just below the critical point where the phases merge
+			T = Tmax - 1e-6*Tmax
+		}
+		val := obj(T)
+
+		if prevVal*val <= 0 && !math.IsNaN(prevVal) && !math.IsNaN(val) {
+			if Tsat, err := solver.Brent(obj, prevT, T, 1e-9); err == nil {
+				sat, err := saturatedStates(state, Tsat)
+				if err == nil {
+					return Tsat, mixtureDensity(sat.RhoL, sat.RhoV, Q), nil
+				}
+			}
+		}
+
+		prevT, prevVal = T, val
+	}
+
+	return 0, 0, newError(ErrOutOfRange, "Flash"+name+"Q", fluidData, name, input, "Q", Q,
+		fmt.Sprintf("no saturation temperature in [%g, %g] matches", Tmin, Tmax))
+}
@@ -0,0 +1,79 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"math"
+	"testing"
+)
+
+func TestQualityFlashes_Water(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	// Wet steam at 400 K with Q = 0.3
+	T_expected := 400.0
+	Q := 0.3
+	sat, err := saturatedStates(core.NewState(f), T_expected)
+	if err != nil {
+		t.Fatalf("saturatedStates failed: %v", err)
+	}
+	rhoExpected := mixtureDensity(sat.RhoL, sat.RhoV, Q)
+
+	tests := []struct {
+		name  string
+		flash func(*fluid.FluidData, float64, float64) (float64, float64, error)
+		value float64
+	}{
+		{"HQ", FlashHQ, (1-Q)*sat.HL + Q*sat.HV},
+		{"SQ", FlashSQ, (1-Q)*sat.SL + Q*sat.SV},
+		{"UQ", FlashUQ, (1-Q)*sat.UL + Q*sat.UV},
+		{"DQ", FlashDQ, rhoExpected},
+	}
+
+	for _, tt := range tests {
+		T, Rho, err := tt.flash(f, tt.value, Q)
+		if err != nil {
+			t.Fatalf("Flash%s failed: %v", tt.name, err)
+		}
+		t.Logf("Flash%s: T=%v K, Rho=%v mol/m³", tt.name, T, Rho)
+
+		if math.Abs(T-T_expected) > 1e-6 {
+			t.Errorf("Flash%s: temperature mismatch: got %v, expected %v", tt.name, T, T_expected)
+		}
+		if math.Abs(Rho-rhoExpected)/rhoExpected > 1e-6 {
+			t.Errorf("Flash%s: density mismatch: got %v, expected %v", tt.name, Rho, rhoExpected)
+		}
+	}
+
+	if _, _, err := FlashHQ(f, sat.HL, 1.5); err == nil {
+		t.Errorf("Expected an error for Q outside [0, 1]")
+	}
+}
+
+func TestFlashHQ_SaturatedVapourMaximum(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	// The saturated vapour enthalpy of water peaks below Tc, so an enthalpy
+	// just under the peak is matched at two temperatures; the lower is
+	// returned.
+	state := core.NewState(f)
+	satLow, _ := saturatedStates(state, 450)
+	satHigh, _ := saturatedStates(state, 550)
+	H_target := math.Min(satLow.HV, satHigh.HV)
+
+	T, _, err := FlashHQ(f, H_target, 1)
+	if err != nil {
+		t.Fatalf("FlashHQ failed: %v", err)
+	}
+	t.Logf("H=%v J/mol: T=%v K", H_target, T)
+
+	if T > 500 {
+		t.Errorf("Expected the lower saturation temperature, got %v", T)
+	}
+}
@@ -0,0 +1,88 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/solver"
+	"fmt"
+	"math"
+)
+
+// scanDensityRoots finds the roots of obj(rho) in [rhoMin, rhoMax] by
+// scanning for sign changes on a log scale and refining each bracket with
+// the safeguarded Halley iteration, for which objDerivs returns obj and its
+// first two density derivatives. Roots are returned in increasing order of
+// density.
+func scanDensityRoots(obj func(float64) float64, objDerivs func(float64) (float64, float64, float64), rhoMin, rhoMax float64) []float64 {
+	const nScan = 200
+	logMin := math.Log(rhoMin)
+	logMax := math.Log(rhoMax)
+	dlog := (logMax - logMin) / float64(nScan)
+
+	roots := make([]float64, 0, 4)
+
+	prevRho := rhoMin
+	prevVal := obj(prevRho)
+	if prevVal == 0 {
+		roots = append(roots, prevRho)
+	}
+
+	for i := 1; i <= nScan; i++ {
+		rho := math.Exp(logMin + dlog*float64(i))
+		val := obj(rho)
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			// Skip regions where EOS blows up
+			prevRho, prevVal = rho, val
+			continue
+		}
+
+		if val == 0 {
+			roots = append(roots, rho)
+		} else if prevVal*val < 0 {
+			// Start from the secant estimate within the bracket
+			guess := prevRho - prevVal*(rho-prevRho)/(val-prevVal)
+			if root, err := solver.Halley(objDerivs, guess, prevRho, rho, 1e-12*rho); err == nil {
+				roots = append(roots, root)
+			}
+		}
+
+		prevRho, prevVal = rho, val
+	}
+
+	return roots
+}
+
+// newtonRoot runs the 2D Newton iteration in (T, rho) from guess and reports
+// whether it converged to a physical root on the branch set by the options.
+// The iteration is kept at positive T and rho, and each step at most
+// doubles them, so it stays near the guess instead of jumping to spurious
+// roots far outside the EOS range.
+func (o options) newtonRoot(fluidData *fluid.FluidData, funcJS func(T, Rho float64) (f1, f2, J11, J12, J21, J22 float64), guess [2]float64, checkDome bool) (float64, float64, bool) {
+	res, err := solver.Newton2DSolve(funcJS, guess[0], guess[1], solver.Newton2DOptions{
+		Tol:        1e-6,
+		MaxIter:    100,
+		StepTol:    1e-13,
+		MaxRelStep: 1.0,
+		Bounds:     &solver.Bounds2D{XMax: math.Inf(1), YMax: math.Inf(1)},
+	})
+	if o.iterations != nil {
+		*o.iterations += res.Iterations
+	}
+
+	T, Rho := res.X, res.Y
+	if d := o.diag; d != nil {
+		d.T, d.Rho = T, Rho
+		d.Iterations += res.Iterations
+		d.Residuals = []float64{res.F1, res.F2}
+		d.Err = err
+	}
+	if err != nil {
+		return 0, 0, false
+	}
+	if !physicalRoot(fluidData, T, Rho, checkDome) || !o.onBranch(fluidData, T, Rho) {
+		if o.diag != nil {
+			o.diag.Err = fmt.Errorf("root at T=%v, rho=%v rejected as unphysical or off the requested branch", T, Rho)
+		}
+		return 0, 0, false
+	}
+	return T, Rho, true
+}
@@ -0,0 +1,72 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/saturation"
+	"GOcoolprop/pkg/solver"
+	"fmt"
+	"math"
+)
+
+// Spinodal returns the vapour and liquid spinodal densities (mol/m³) at
+// temperature T below Tc, where ∂P/∂ρ = 0 on the isotherm.
+// Between the saturated density of a phase and its spinodal the EOS
+// describes metastable states (subcooled vapour, superheated liquid);
+// between the two spinodals it is mechanically unstable.
+func Spinodal(fluidData *fluid.FluidData, T float64) (rhoV, rhoL float64, err error) {
+	if T >= fluidData.States.Critical.T {
+		return 0, 0, &Error{Kind: ErrOutOfRange, Op: "Spinodal", Fluid: fluidData.Info.Name,
+			Inputs: []Input{{"T", T}}, Detail: fmt.Sprintf("not below Tc=%v", fluidData.States.Critical.T)}
+	}
+
+	rhoVsat, err := saturation.RhoV(fluidData, T)
+	if err != nil {
+		return 0, 0, err
+	}
+	rhoLsat, err := saturation.RhoL(fluidData, T)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	state := core.NewState(fluidData)
+	dPdRho := func(rho float64) float64 {
+		state.Update(T, rho)
+		return state.DPdRho()
+	}
+
+	// Scan past both saturated densities, since the ancillaries can place
+	// them beyond the spinodals close to Tc. Multiparameter EOS may have
+	// several loops inside the dome, so keep the outermost zeros: the first
+	// falling one from the vapour side and the last rising one.
+	lo := 0.5 * rhoVsat
+	hi := rhoLsat + 0.5*(rhoLsat-rhoVsat)
+	const nScan = 1000
+	dRho := (hi - lo) / float64(nScan)
+
+	rhoV, rhoL = math.NaN(), math.NaN()
+	prevRho := lo
+	prevVal := dPdRho(prevRho)
+	for i := 1; i <= nScan; i++ {
+		rho := lo + dRho*float64(i)
+		val := dPdRho(rho)
+
+		if prevVal > 0 && val <= 0 && math.IsNaN(rhoV) {
+			if root, err := solver.Brent(dPdRho, prevRho, rho, 1e-12*rho); err == nil {
+				rhoV = root
+			}
+		} else if prevVal <= 0 && val > 0 {
+			if root, err := solver.Brent(dPdRho, prevRho, rho, 1e-12*rho); err == nil {
+				rhoL = root
+			}
+		}
+
+		prevRho, prevVal = rho, val
+	}
+
+	if math.IsNaN(rhoV) || math.IsNaN(rhoL) || rhoV >= rhoL {
+		return 0, 0, &Error{Kind: ErrNoConvergence, Op: "Spinodal", Fluid: fluidData.Info.Name,
+			Inputs: []Input{{"T", T}}, Detail: "no spinodal found"}
+	}
+	return rhoV, rhoL, nil
+}
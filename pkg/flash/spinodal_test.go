@@ -0,0 +1,142 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/phase"
+	"GOcoolprop/pkg/saturation"
+	"math"
+	"testing"
+)
+
+func TestSpinodal(t *testing.T) {
+	tests := []struct {
+		fluid string
+		T     float64
+	}{
+		{"Water", 400},
+		{"Water", 600},
+		{"Nitrogen", 120},
+		{"CarbonDioxide", 280},
+	}
+
+	for _, tt := range tests {
+		f, err := fluid.LoadFluidByName(tt.fluid, "../../data")
+		if err != nil {
+			t.Fatalf("Failed to load %s: %v", tt.fluid, err)
+		}
+
+		rhoV, rhoL, err := Spinodal(f, tt.T)
+		if err != nil {
+			t.Fatalf("Spinodal failed for %s at T=%v: %v", tt.fluid, tt.T, err)
+		}
+
+		rhoVsat, _ := saturation.RhoV(f, tt.T)
+		rhoLsat, _ := saturation.RhoL(f, tt.T)
+		Psat, _ := saturation.Psat(f, tt.T)
+
+		state := core.NewState(f)
+		state.Update(tt.T, rhoV)
+		PV, dPdRhoV := state.Pressure(), state.DPdRho()
+		state.Update(tt.T, rhoL)
+		PL, dPdRhoL := state.Pressure(), state.DPdRho()
+
+		t.Logf("%s T=%v: rhoV=%v (sat %v, P=%v), rhoL=%v (sat %v, P=%v), Psat=%v",
+			tt.fluid, tt.T, rhoV, rhoVsat, PV, rhoL, rhoLsat, PL, Psat)
+
+		// ∂P/∂ρ vanishes relative to its ideal-gas scale R·T
+		R := f.EOS[0].GasConstant
+		if math.Abs(dPdRhoV) > 1e-6*R*tt.T || math.Abs(dPdRhoL) > 1e-6*R*tt.T {
+			t.Errorf("%s T=%v: dP/drho not zero at spinodals: %v, %v", tt.fluid, tt.T, dPdRhoV, dPdRhoL)
+		}
+
+		// Metastable ranges lie between the saturated densities and the spinodals
+		if !(rhoVsat < rhoV && rhoV < rhoL && rhoL < rhoLsat) {
+			t.Errorf("%s T=%v: spinodals not inside the dome", tt.fluid, tt.T)
+		}
+		if !(PL < Psat && Psat < PV) {
+			t.Errorf("%s T=%v: expected P(rhoL)=%v < Psat=%v < P(rhoV)=%v", tt.fluid, tt.T, PL, Psat, PV)
+		}
+	}
+}
+
+func TestFlashPH_Metastable(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	// Slightly inside the dome at 1 atm on the liquid side
+	P_target := 101325.0
+	state := core.NewState(f)
+	Tsat, _ := saturation.Tsat(f, P_target)
+	sat, err := saturatedStates(state, Tsat)
+	if err != nil {
+		t.Fatalf("saturatedStates failed: %v", err)
+	}
+	H_target := sat.HL + 0.01*(sat.HV-sat.HL)
+
+	_, _, Q, err := FlashPH(f, P_target, H_target)
+	if err != nil || Q < 0 {
+		t.Fatalf("Expected a stable two-phase state, got Q=%v, err=%v", Q, err)
+	}
+
+	// Superheated liquid
+	T, Rho, Q, err := FlashPH(f, P_target, H_target, WithMetastable())
+	if err != nil {
+		t.Fatalf("Metastable FlashPH failed: %v", err)
+	}
+	t.Logf("Superheated liquid: T=%v K (Tsat=%v), Rho=%v mol/m³, Q=%v", T, Tsat, Rho, Q)
+
+	rhoVspin, rhoLspin, _ := Spinodal(f, T)
+	if Q != -1 || T <= Tsat || Rho < rhoLspin {
+		t.Errorf("Expected a superheated liquid above Tsat and the spinodal %v", rhoLspin)
+	}
+	state.Update(T, Rho)
+	if math.Abs(state.Pressure()-P_target) > 1e-3 || math.Abs(state.MolarEnthalpy()-H_target) > 1e-3 {
+		t.Errorf("Metastable root does not reproduce P, H: %v, %v", state.Pressure(), state.MolarEnthalpy())
+	}
+
+	// Subcooled vapour on the vapour side
+	H_target = sat.HL + 0.99*(sat.HV-sat.HL)
+	T, Rho, Q, err = FlashPH(f, P_target, H_target, WithMetastable())
+	if err != nil {
+		t.Fatalf("Metastable FlashPH failed on the vapour side: %v", err)
+	}
+	t.Logf("Subcooled vapour: T=%v K, Rho=%v mol/m³, Q=%v", T, Rho, Q)
+
+	rhoVspin, _, _ = Spinodal(f, T)
+	if Q != -1 || T >= Tsat || Rho > rhoVspin {
+		t.Errorf("Expected a subcooled vapour below Tsat and the spinodal %v", rhoVspin)
+	}
+}
+
+func TestFlashTU_Metastable(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Nitrogen", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Nitrogen: %v", err)
+	}
+
+	// Vapour subcooled below its saturated density at 100 K
+	T := 100.0
+	rhoVsat, _ := saturation.RhoV(f, T)
+	rhoVspin, _, err := Spinodal(f, T)
+	if err != nil {
+		t.Fatalf("Spinodal failed: %v", err)
+	}
+	rhoExpected := rhoVsat + 0.3*(rhoVspin-rhoVsat)
+
+	state := core.NewState(f)
+	state.Update(T, rhoExpected)
+	U_target := state.MolarInternalEnergy()
+
+	rho, Q, err := FlashTU(f, T, U_target, WithPhase(phase.Gas), WithMetastable())
+	if err != nil {
+		t.Fatalf("Metastable FlashTU failed: %v", err)
+	}
+	t.Logf("Result: rho=%v mol/m³ (expected %v), Q=%v", rho, rhoExpected, Q)
+
+	if Q != -1 || math.Abs(rho-rhoExpected)/rhoExpected > 1e-6 {
+		t.Errorf("Metastable vapour density mismatch: got %v, expected %v", rho, rhoExpected)
+	}
+}
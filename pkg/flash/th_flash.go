@@ -6,19 +6,53 @@ import (
 
 	"GOcoolprop/pkg/core"
 	"GOcoolprop/pkg/fluid"
-	"GOcoolprop/pkg/solver"
+	"GOcoolprop/pkg/phase"
 )
 
 // FlashTH solves for density given temperature and molar enthalpy.
-// Returns density in mol/m³.
-func FlashTH(fluidData *fluid.FluidData, T, H_target float64) (float64, error) {
+// Returns density in mol/m³. See WithPhase for imposing the phase; with
+// phase.TwoPhase the result is the density of the saturated mixture at T.
+func FlashTH(fluidData *fluid.FluidData, T, H_target float64, opts ...Option) (float64, error) {
+	o := collectOptions(opts)
+	if err := o.checkRange(fluidData, "FlashTH", "T", T, "H", H_target, T, math.NaN()); err != nil {
+		return 0, err
+	}
+	Rho, err := flashTH(fluidData, T, H_target, o)
+	if err == nil && o.phase != phase.TwoPhase {
+		err = o.checkRange(fluidData, "FlashTH", "T", T, "H", H_target, math.NaN(), singlePhasePressure(fluidData, T, Rho, -1))
+	}
+	if err != nil {
+		return 0, err
+	}
+	return Rho, nil
+}
+
+// flashTH is FlashTH without the range checks.
+func flashTH(fluidData *fluid.FluidData, T, H_target float64, o options) (float64, error) {
 	state := core.NewState(fluidData)
 
+	// ---- Imposed two-phase state ----
+	if o.phase == phase.TwoPhase {
+		sat, err := saturatedStates(state, T)
+		if err != nil {
+			return 0, err
+		}
+		if H_target < sat.HL || H_target > sat.HV {
+			return 0, newError(ErrOutOfRange, "FlashTH", fluidData, "T", T, "H", H_target, "not a two-phase state")
+		}
+		Q := (H_target - sat.HL) / (sat.HV - sat.HL)
+		return mixtureDensity(sat.RhoL, sat.RhoV, Q), nil
+	}
+
 	// Objective: H(T, rho) - H_target = 0
 	obj := func(rho float64) float64 {
 		state.Update(T, rho)
 		return state.MolarEnthalpy() - H_target
 	}
+	objDerivs := func(rho float64) (float64, float64, float64) {
+		state.Update(T, rho)
+		return state.MolarEnthalpy() - H_target, state.DHdRho(), state.D2HdRho2()
+	}
 
 	rhoCrit := fluidData.States.Critical.RhoMolar
 	rhoTripleLiq := fluidData.States.TripleLiquid.RhoMolar
@@ -38,7 +72,7 @@ func FlashTH(fluidData *fluid.FluidData, T, H_target float64) (float64, error) {
 	state.Update(T, rhoLiqGuess)
 	H_liq := state.MolarEnthalpy()
 
-	preferLiquid := math.Abs(H_target-H_liq) < math.Abs(H_target-H_gas)
+	preferLiquid := o.liquidBranch(math.Abs(H_target-H_liq) < math.Abs(H_target-H_gas))
 
 	// ---- Global density range to search ----
 	// Low end: very dilute gas; High end: comfortably above typical liquid density.
@@ -47,105 +81,25 @@ func FlashTH(fluidData *fluid.FluidData, T, H_target float64) (float64, error) {
 	if rhoMax == 0 {
 		rhoMax = rhoCrit * 5.0
 	}
+	rhoMin, rhoMax = o.densityRange(fluidData, T, rhoMin, rhoMax)
 	if rhoMax <= rhoMin {
-		return 0, fmt.Errorf("invalid rho range [%g, %g]", rhoMin, rhoMax)
+		return 0, newError(ErrOutOfRange, "FlashTH", fluidData, "T", T, "H", H_target, fmt.Sprintf("empty density range [%g, %g]", rhoMin, rhoMax))
 	}
 
 	// ---- Scan for sign changes on log scale ----
 
-	const nScan = 200 // tune if needed
-	logMin := math.Log(rhoMin)
-	logMax := math.Log(rhoMax)
-	dlog := (logMax - logMin) / float64(nScan)
-
-	type rootInfo struct {
-		rho float64
-	}
-	roots := make([]rootInfo, 0, 4)
-
-	// Helper to de-duplicate roots
-	addRoot := func(r float64) {
-		if r <= 0 || math.IsNaN(r) || math.IsInf(r, 0) {
-			return
-		}
-		const relTol = 1e-6
-		for _, rr := range roots {
-			if math.Abs(r-rr.rho) <= relTol*math.Max(1.0, math.Abs(rr.rho)) {
-				return // already have a root here
-			}
-		}
-		roots = append(roots, rootInfo{rho: r})
-	}
-
-	// Initial point
-	prevRho := rhoMin
-	prevVal := obj(prevRho)
-	if math.IsNaN(prevVal) || math.IsInf(prevVal, 0) {
-		// Try to move a bit inward if the edge is pathological
-		prevRho = math.Exp(logMin + dlog)
-		prevVal = obj(prevRho)
-	}
-
-	for i := 1; i <= nScan; i++ {
-		rho := math.Exp(logMin + dlog*float64(i))
-		if rho <= prevRho {
-			continue
-		}
-
-		val := obj(rho)
-		if math.IsNaN(val) || math.IsInf(val, 0) {
-			// Skip regions where EOS blows up
-			prevRho, prevVal = rho, val
-			continue
-		}
-
-		// Direct hit?
-		if val == 0 {
-			addRoot(rho)
-		}
-
-		// Sign change bracket [prevRho, rho]
-		if prevVal*val < 0 {
-			a := prevRho
-			b := rho
-			// Safety: ensure increasing order
-			if a > b {
-				a, b = b, a
-			}
-
-			// Use the same tol semantics as your existing usage: on H.
-			const tolH = 1.0 // J/mol
-			root, err := solver.Brent(obj, a, b, tolH)
-			if err == nil {
-				addRoot(root)
-			}
-		}
-
-		prevRho, prevVal = rho, val
-	}
-
+	roots := scanDensityRoots(obj, objDerivs, rhoMin, rhoMax)
 	if len(roots) == 0 {
-		return 0, fmt.Errorf("FlashTH: no root found for T=%g K, H=%g J/mol", T, H_target)
+		return 0, newError(ErrNoConvergence, "FlashTH", fluidData, "T", T, "H", H_target, fmt.Sprintf("no root in the density range [%g, %g]", rhoMin, rhoMax))
 	}
 
 	// ---- Pick the "most physical" root given the phase hint ----
 
-	chosen := roots[0].rho
+	// Roots are in increasing order of density
 	if preferLiquid {
 		// For liquid-like H, prefer the highest density root
-		for _, r := range roots {
-			if r.rho > chosen {
-				chosen = r.rho
-			}
-		}
-	} else {
-		// For gas-like H, prefer the lowest density root
-		for _, r := range roots {
-			if r.rho < chosen {
-				chosen = r.rho
-			}
-		}
+		return roots[len(roots)-1], nil
 	}
-
-	return chosen, nil
+	// For gas-like H, prefer the lowest density root
+	return roots[0], nil
 }
@@ -0,0 +1,60 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/saturation"
+	"fmt"
+	"math"
+)
+
+// FlashTPLiquid solves for the liquid-branch density given temperature and
+// pressure, with T below Tc and P above Psat(T).
+// Newton's method on P(rho) starts from the saturated liquid density, where
+// the isotherm is steep and convex, and uses the analytic ∂P/∂ρ.
+// Returns density in mol/m³.
+func FlashTPLiquid(fluidData *fluid.FluidData, T, P_target float64) (float64, error) {
+	rho, err := saturation.RhoL(fluidData, T)
+	if err != nil {
+		return 0, err
+	}
+	if rho <= 0 {
+		return 0, newError(ErrOutOfRange, "FlashTPLiquid", fluidData, "T", T, "P", P_target, fmt.Sprintf("invalid saturated liquid density %v", rho))
+	}
+	rhoSat := rho
+
+	state := core.NewState(fluidData)
+
+	const maxIter = 50
+	var dP float64
+	for i := 0; i < maxIter; i++ {
+		state.Update(T, rho)
+		dP = state.Pressure() - P_target
+		dPdRho := state.DPdRho()
+
+		if math.Abs(dP) <= 1e-10*math.Max(math.Abs(P_target), 1.0) {
+			return rho, nil
+		}
+		if dPdRho <= 0 || math.IsNaN(dPdRho) {
+			e := newError(ErrNoConvergence, "FlashTPLiquid", fluidData, "T", T, "P", P_target, "left the liquid branch")
+			e.T, e.Rho, e.Iterations, e.Residuals = T, rho, i, []float64{dP}
+			return 0, e
+		}
+
+		step := -dP / dPdRho
+		// Stay on the liquid side of the spinodal: never drop below half the
+		// saturated density
+		for rho+step < 0.5*rhoSat {
+			step *= 0.5
+		}
+		rho += step
+
+		if math.Abs(step) <= 1e-12*rho {
+			return rho, nil
+		}
+	}
+
+	e := newError(ErrNoConvergence, "FlashTPLiquid", fluidData, "T", T, "P", P_target, "")
+	e.T, e.Rho, e.Iterations, e.Residuals = T, rho, maxIter, []float64{dP}
+	return 0, e
+}
@@ -0,0 +1,42 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/fluid"
+	"math"
+	"testing"
+)
+
+func TestFlashTPLiquid_Water_IAPWS95(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	// Compressed-liquid check values from IAPWS-95 (Wagner & Pruß 2002, Table 7)
+	const M = 0.018015268 // kg/mol
+	tests := []struct {
+		T   float64 // K
+		P   float64 // Pa
+		rho float64 // kg/m³
+	}{
+		{300, 20.0022515e6, 1005.308},
+		{300, 700.004704e6, 1188.202},
+		{500, 10.0003858e6, 838.025},
+		{500, 700.000405e6, 1084.564},
+	}
+
+	for _, tt := range tests {
+		rho, err := FlashTPLiquid(f, tt.T, tt.P)
+		if err != nil {
+			t.Fatalf("FlashTPLiquid failed for T=%v, P=%v: %v", tt.T, tt.P, err)
+		}
+
+		rhoMass := rho * M
+		relError := math.Abs(rhoMass-tt.rho) / tt.rho
+		t.Logf("T=%v K, P=%v Pa: rho=%v kg/m³, expected %v (error %.2e)", tt.T, tt.P, rhoMass, tt.rho, relError)
+
+		if relError > 1e-6 {
+			t.Errorf("Density mismatch at T=%v, P=%v: got %v, expected %v", tt.T, tt.P, rhoMass, tt.rho)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/phase"
+	"fmt"
+	"math"
+)
+
+// FlashTU solves for density given temperature and molar internal energy.
+// Returns Rho (mol/m³) and the vapour quality Q.
+// Q is -1 for single-phase states. See WithPhase for imposing the phase.
+func FlashTU(fluidData *fluid.FluidData, T, U_target float64, opts ...Option) (float64, float64, error) {
+	o := collectOptions(opts)
+	if err := o.checkRange(fluidData, "FlashTU", "T", T, "U", U_target, T, math.NaN()); err != nil {
+		return 0, 0, err
+	}
+	Rho, Q, err := flashTU(fluidData, T, U_target, o)
+	if err == nil {
+		err = o.checkRange(fluidData, "FlashTU", "T", T, "U", U_target, math.NaN(), singlePhasePressure(fluidData, T, Rho, Q))
+	}
+	if err == nil {
+		err = o.checkSaturated(fluidData, "FlashTU", "T", T, "U", U_target, T, Q)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	return Rho, Q, nil
+}
+
+// flashTU is FlashTU without the range checks.
+func flashTU(fluidData *fluid.FluidData, T, U_target float64, o options) (float64, float64, error) {
+	state := core.NewState(fluidData)
+
+	// Objective: U(T, rho) - U_target = 0
+	obj := func(rho float64) float64 {
+		state.Update(T, rho)
+		return state.MolarInternalEnergy() - U_target
+	}
+	objDerivs := func(rho float64) (float64, float64, float64) {
+		state.Update(T, rho)
+		return state.MolarInternalEnergy() - U_target, state.DUdRho(), state.D2UdRho2()
+	}
+
+	rhoCrit := fluidData.States.Critical.RhoMolar
+	rhoTripleLiq := fluidData.States.TripleLiquid.RhoMolar
+	if rhoTripleLiq == 0 {
+		rhoTripleLiq = rhoCrit * 2.5
+	}
+
+	// Global density range, as in FlashTH
+	rhoMin := 1e-8
+	rhoMax := rhoTripleLiq * 3.0
+
+	// ---- Two-phase check against the saturated states at T ----
+	// Below Tc the target also selects the branch to search. An imposed
+	// single phase skips the check and searches its own branch.
+	Tmin, Tmax := saturationRange(fluidData)
+	if T >= Tmin && T < Tmax && o.checkTwoPhase() {
+		if sat, err := saturatedStates(state, T); err == nil {
+			if U_target >= sat.UL && U_target <= sat.UV {
+				Q := (U_target - sat.UL) / (sat.UV - sat.UL)
+				if o.returnTwoPhase() {
+					return mixtureDensity(sat.RhoL, sat.RhoV, Q), Q, nil
+				}
+				// Metastable root: search its branch up to the spinodal
+				o.metastableBranch(Q)
+			} else if U_target < sat.UL {
+				rhoMin = sat.RhoL
+			} else {
+				rhoMax = sat.RhoV
+			}
+		}
+	}
+
+	if o.phase == phase.TwoPhase {
+		return 0, 0, newError(ErrOutOfRange, "FlashTU", fluidData, "T", T, "U", U_target, "not a two-phase state")
+	}
+	rhoMin, rhoMax = o.densityRange(fluidData, T, rhoMin, rhoMax)
+
+	roots := scanDensityRoots(obj, objDerivs, rhoMin, rhoMax)
+	if len(roots) == 0 {
+		return 0, 0, newError(ErrNoConvergence, "FlashTU", fluidData, "T", T, "U", U_target, fmt.Sprintf("no root in the density range [%g, %g]", rhoMin, rhoMax))
+	}
+
+	// The root closest to the saturated phase (or the dilute gas above Tc)
+	// is the stable one; further roots lie beyond the physical range.
+	return roots[0], -1, nil
+}
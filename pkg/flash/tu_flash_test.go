@@ -0,0 +1,105 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/saturation"
+	"math"
+	"testing"
+)
+
+func TestFlashTU_Nitrogen_Gas(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Nitrogen", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Nitrogen: %v", err)
+	}
+
+	// Test case: Nitrogen gas at 300K
+	T := 300.0
+	rhoExpected := 40.6
+
+	state := core.NewState(f)
+	state.Update(T, rhoExpected)
+	U_target := state.MolarInternalEnergy()
+
+	t.Logf("Test: T=%v K, rho=%v mol/m³, U=%v J/mol", T, rhoExpected, U_target)
+
+	rhoResult, Q, err := FlashTU(f, T, U_target)
+	if err != nil {
+		t.Fatalf("FlashTU failed: %v", err)
+	}
+
+	relError := math.Abs(rhoResult-rhoExpected) / rhoExpected
+	t.Logf("Result: rho=%v mol/m³, Q=%v, relative error=%v%%", rhoResult, Q, relError*100)
+
+	if relError > 0.01 { // 1% tolerance
+		t.Errorf("Density mismatch: got %v, expected %v (error %.2f%%)",
+			rhoResult, rhoExpected, relError*100)
+	}
+}
+
+func TestFlashTU_Water_Liquid(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	// Test case: Liquid water at 300K, compressed
+	T := 300.0
+	rhoExpected := 55500.0
+
+	state := core.NewState(f)
+	state.Update(T, rhoExpected)
+	U_target := state.MolarInternalEnergy()
+
+	t.Logf("Test: T=%v K, rho=%v mol/m³, U=%v J/mol", T, rhoExpected, U_target)
+
+	rhoResult, _, err := FlashTU(f, T, U_target)
+	if err != nil {
+		t.Fatalf("FlashTU failed: %v", err)
+	}
+
+	relError := math.Abs(rhoResult-rhoExpected) / rhoExpected
+	t.Logf("Result: rho=%v mol/m³, relative error=%v%%", rhoResult, relError*100)
+
+	if relError > 0.01 { // 1% tolerance
+		t.Errorf("Density mismatch: got %v, expected %v (error %.2f%%)",
+			rhoResult, rhoExpected, relError*100)
+	}
+}
+
+func TestFlashTU_Water_TwoPhase(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	// Test case: wet steam at 350K with 25% quality
+	T := 350.0
+	Q_expected := 0.25
+
+	rhoL, _ := saturation.RhoL(f, T)
+	rhoV, _ := saturation.RhoV(f, T)
+
+	state := core.NewState(f)
+	state.Update(T, rhoL)
+	UL := state.MolarInternalEnergy()
+	state.Update(T, rhoV)
+	UV := state.MolarInternalEnergy()
+
+	rhoResult, Q, err := FlashTU(f, T, UL+Q_expected*(UV-UL))
+	if err != nil {
+		t.Fatalf("FlashTU failed: %v", err)
+	}
+
+	t.Logf("Result: rho=%v mol/m³, Q=%v", rhoResult, Q)
+
+	if math.Abs(Q-Q_expected) > 1e-9 {
+		t.Errorf("Quality mismatch: got %v, expected %v", Q, Q_expected)
+	}
+
+	rhoExpected := 1.0 / (Q_expected/rhoV + (1-Q_expected)/rhoL)
+	if math.Abs(rhoResult-rhoExpected)/rhoExpected > 1e-9 {
+		t.Errorf("Density mismatch: got %v, expected %v", rhoResult, rhoExpected)
+	}
+}
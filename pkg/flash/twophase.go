@@ -0,0 +1,130 @@
+package flash
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/saturation"
+	"GOcoolprop/pkg/solver"
+	"fmt"
+	"math"
+)
+
+// satStates holds the saturated liquid and vapour states at temperature T,
+// using the ancillary densities and evaluating the EOS at each of them.
+type satStates struct {
+	T          float64
+	RhoL, RhoV float64
+	HL, HV     float64
+	SL, SV     float64
+	UL, UV     float64
+}
+
+// saturationRange returns the temperature range over which the saturation
+// ancillaries can be evaluated.
+func saturationRange(fluidData *fluid.FluidData) (Tmin, Tmax float64) {
+	Tmin = fluidData.Ancillaries.PS.TMin
+	if Tmin == 0 {
+		Tmin = fluidData.States.TripleLiquid.T
+	}
+	Tmax = fluidData.States.Critical.T
+	if fluidData.Ancillaries.PS.TMax > 0 && fluidData.Ancillaries.PS.TMax < Tmax {
+		Tmax = fluidData.Ancillaries.PS.TMax
+	}
+	return Tmin, Tmax
+}
+
+// saturatedStates evaluates the saturated liquid and vapour at T.
+// The state is left at the saturated vapour point.
+func saturatedStates(state *core.State, T float64) (satStates, error) {
+	rhoL, err := saturation.RhoL(state.Fluid, T)
+	if err != nil {
+		return satStates{}, err
+	}
+	rhoV, err := saturation.RhoV(state.Fluid, T)
+	if err != nil {
+		return satStates{}, err
+	}
+	if rhoL <= 0 || rhoV <= 0 {
+		return satStates{}, &Error{Kind: ErrOutOfRange, Op: "saturatedStates", Fluid: state.Fluid.Info.Name,
+			Inputs: []Input{{"T", T}}, Detail: fmt.Sprintf("invalid saturation densities rhoL=%v, rhoV=%v", rhoL, rhoV)}
+	}
+
+	sat := satStates{T: T, RhoL: rhoL, RhoV: rhoV}
+
+	state.Update(T, rhoL)
+	sat.HL = state.MolarEnthalpy()
+	sat.SL = state.MolarEntropy()
+	sat.UL = state.MolarInternalEnergy()
+
+	state.Update(T, rhoV)
+	sat.HV = state.MolarEnthalpy()
+	sat.SV = state.MolarEntropy()
+	sat.UV = state.MolarInternalEnergy()
+
+	return sat, nil
+}
+
+// mixtureDensity returns the density of a two-phase mixture with vapour
+// quality Q, from the specific volumes of the saturated phases.
+func mixtureDensity(rhoL, rhoV, Q float64) float64 {
+	v := Q/rhoV + (1-Q)/rhoL
+	return 1.0 / v
+}
+
+// domeMargin is the fraction of the dome width (rhoL - rhoV) kept clear on
+// each side, so that stable states next to the saturation curve are not
+// rejected because of the error in the ancillary densities.
+const domeMargin = 0.01
+
+// insideDome reports whether (T, Rho) lies between the saturated liquid and
+// vapour densities, i.e. the single-phase EOS root is metastable or unstable.
+func insideDome(fluidData *fluid.FluidData, T, Rho float64) bool {
+	Tmin, Tmax := saturationRange(fluidData)
+	if T < Tmin || T >= Tmax {
+		return false
+	}
+	rhoL, errL := saturation.RhoL(fluidData, T)
+	rhoV, errV := saturation.RhoV(fluidData, T)
+	if errL != nil || errV != nil {
+		return false
+	}
+	margin := domeMargin * (rhoL - rhoV)
+	return Rho > rhoV+margin && Rho < rhoL-margin
+}
+
+// physicalRoot reports whether a converged single-phase (T, Rho) is
+// acceptable: positive, not far below the triple point where the EOS has
+// spurious roots and, if checkDome is set, not inside the two-phase dome.
+func physicalRoot(fluidData *fluid.FluidData, T, Rho float64, checkDome bool) bool {
+	if T <= 0 || Rho <= 0 || math.IsNaN(T) || math.IsNaN(Rho) {
+		return false
+	}
+	if Tmin, _ := saturationRange(fluidData); T < 0.95*Tmin {
+		return false
+	}
+	return !checkDome || !insideDome(fluidData, T, Rho)
+}
+
+// branchTemperature returns the temperature at which prop, evaluated on the
+// saturated states, equals target. The result is clamped to Tmin or Tmax when
+// the target lies beyond the end of the branch.
+func branchTemperature(state *core.State, prop func(satStates) float64, target, Tmin, Tmax float64) float64 {
+	obj := func(T float64) float64 {
+		sat, err := saturatedStates(state, T)
+		if err != nil {
+			return math.NaN()
+		}
+		return prop(sat) - target
+	}
+
+	fMin, fMax := obj(Tmin), obj(Tmax)
+	if fMin*fMax < 0 {
+		if T, err := solver.Brent(obj, Tmin, Tmax, 1e-6); err == nil {
+			return T
+		}
+	}
+	if math.Abs(fMax) < math.Abs(fMin) {
+		return Tmax
+	}
+	return Tmin
+}
@@ -25,14 +25,20 @@ func (ac *AncillaryCurve) Evaluate(T float64) float64 {
 		sum += ac.N[i] * math.Pow(theta, ac.T[i])
 	}
 
+	// The exponential forms multiply the sum by Tc/T only if using_tau_r is set
+	tauR := 1.0
+	if ac.UsingTauR {
+		tauR = Tc / T
+	}
+
 	switch ac.Type {
 	case "pV", "pL":
-		// p = pc * exp( (Tc/T) * sum )
-		return ac.ReducingValue * math.Exp((Tc/T)*sum)
+		// p = pc * exp( tauR * sum )
+		return ac.ReducingValue * math.Exp(tauR*sum)
 
 	case "rhoV":
-		// rho = rhoc * exp( (Tc/T) * sum )
-		return ac.ReducingValue * math.Exp((Tc/T)*sum)
+		// rho = rhoc * exp( tauR * sum )
+		return ac.ReducingValue * math.Exp(tauR*sum)
 
 	case "rhoLnoexp":
 		// rho = rhoc * (1 + sum)
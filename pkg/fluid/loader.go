@@ -17,10 +17,41 @@ func LoadFluid(path string) (*FluidData, error) {
 	if err := json.Unmarshal(data, &fluid); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal fluid data: %w", err)
 	}
+	fluid.Dir = filepath.Dir(path)
 
+	if err := checkAlpha0(&fluid); err != nil {
+		return nil, err
+	}
 	return &fluid, nil
 }
 
+// alpha0Types are the ideal-gas Helmholtz term types core.NewState builds.
+var alpha0Types = map[string]bool{
+	"IdealGasHelmholtzLead":                      true,
+	"IdealGasHelmholtzLogTau":                    true,
+	"IdealGasHelmholtzPower":                     true,
+	"IdealGasHelmholtzPlanckEinstein":            true,
+	"IdealGasHelmholtzPlanckEinsteinFunctionT":   true,
+	"IdealGasHelmholtzPlanckEinsteinGeneralized": true,
+	"IdealGasHelmholtzCP0Constant":               true,
+	"IdealGasHelmholtzCP0PolyT":                  true,
+	"IdealGasHelmholtzCP0AlyLee":                 true,
+	"IdealGasHelmholtzEnthalpyEntropyOffset":     true,
+}
+
+// checkAlpha0 rejects ideal-gas terms that would otherwise be dropped,
+// silently giving a wrong heat capacity.
+func checkAlpha0(f *FluidData) error {
+	for _, eos := range f.EOS {
+		for _, term := range eos.Alpha0 {
+			if !alpha0Types[term.Type] {
+				return fmt.Errorf("%s: unsupported ideal-gas Helmholtz term %q", f.Info.Name, term.Type)
+			}
+		}
+	}
+	return nil
+}
+
 // LoadFluidByName loads a fluid by name from a directory
 // Uses the fluid registry to resolve aliases
 func LoadFluidByName(name, dataDir string) (*FluidData, error) {
@@ -33,3 +33,41 @@ func TestLoadWater(t *testing.T) {
 		t.Error("No AlphaR terms")
 	}
 }
+
+func TestLoadTransportModels(t *testing.T) {
+	// R32 lists an entropy scaling viscosity model and an ECS fallback
+	f, err := LoadFluid("../../data/R32.json")
+	if err != nil {
+		t.Fatalf("Failed to load R32.json: %v", err)
+	}
+	visc := f.Transport.Viscosity
+	if len(visc) != 2 || visc[0].Type != "rhosr-CS" || visc[1].Type != "ECS" {
+		t.Errorf("Unexpected R32 viscosity models: %+v", visc)
+	}
+
+	// A single correlation is a list of one
+	f, err = LoadFluid("../../data/Nitrogen.json")
+	if err != nil {
+		t.Fatalf("Failed to load Nitrogen.json: %v", err)
+	}
+	if len(f.Transport.Viscosity) != 1 || f.Transport.Viscosity[0].Dilute == nil {
+		t.Errorf("Unexpected Nitrogen viscosity models: %+v", f.Transport.Viscosity)
+	}
+	if len(f.Transport.Conductivity) != 1 {
+		t.Errorf("Unexpected Nitrogen conductivity models: %+v", f.Transport.Conductivity)
+	}
+}
+
+func TestCheckAlpha0(t *testing.T) {
+	f, err := LoadFluid("../../data/R22.json")
+	if err != nil {
+		t.Fatalf("Failed to load R22: %v", err)
+	}
+	if err := checkAlpha0(f); err != nil {
+		t.Errorf("R22: %v", err)
+	}
+	f.EOS[0].Alpha0 = append(f.EOS[0].Alpha0, Alpha0Term{Type: "IdealGasHelmholtzGERG2004Cosh"})
+	if err := checkAlpha0(f); err == nil {
+		t.Errorf("expected an error for an unsupported term")
+	}
+}
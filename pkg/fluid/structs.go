@@ -1,26 +1,90 @@
 package fluid
 
+import (
+	"bytes"
+	"encoding/json"
+)
+
 type FluidData struct {
 	Ancillaries Ancillaries `json:"ANCILLARIES"`
 	EOS         []EOS       `json:"EOS"`
 	Info        Info        `json:"INFO"`
 	States      States      `json:"STATES"`
 	Transport   Transport   `json:"TRANSPORT"`
+
+	// Directory the fluid file was loaded from, for loading the reference
+	// fluids of ECS transport models
+	Dir string `json:"-"`
 }
 
 type Transport struct {
-	Viscosity      ViscosityData      `json:"viscosity"`
-	Conductivity   ConductivityData   `json:"conductivity"`
+	Viscosity      ViscosityModels    `json:"viscosity"`
+	Conductivity   ConductivityModels `json:"conductivity"`
 	SurfaceTension SurfaceTensionData `json:"surface_tension"`
 }
 
+// ViscosityModels lists the viscosity correlations of a fluid in order of
+// preference. Fluid files give either one correlation or a list of
+// alternatives, e.g. an entropy scaling model followed by an ECS fallback.
+type ViscosityModels []ViscosityData
+
+func (m *ViscosityModels) UnmarshalJSON(data []byte) error {
+	return unmarshalModels(data, (*[]ViscosityData)(m))
+}
+
+// ConductivityModels lists the conductivity correlations of a fluid in
+// order of preference, like ViscosityModels.
+type ConductivityModels []ConductivityData
+
+func (m *ConductivityModels) UnmarshalJSON(data []byte) error {
+	return unmarshalModels(data, (*[]ConductivityData)(m))
+}
+
+// unmarshalModels decodes a JSON object or array of objects into a list.
+func unmarshalModels[T any](data []byte, models *[]T) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if bytes.HasPrefix(data, []byte("[")) {
+		return json.Unmarshal(data, models)
+	}
+	var model T
+	if err := json.Unmarshal(data, &model); err != nil {
+		return err
+	}
+	*models = []T{model}
+	return nil
+}
+
 type ViscosityData struct {
-	BibTeX       string           `json:"BibTeX"`
-	Hardcoded    string           `json:"hardcoded"`
-	Dilute       *ViscosityDilute `json:"dilute"`
-	HigherOrder  *ViscosityHigher `json:"higher_order"`
-	SigmaEta     float64          `json:"sigma_eta"`
-	EpsilonOverK float64          `json:"epsilon_over_k"`
+	BibTeX       string            `json:"BibTeX"`
+	Type         string            `json:"type"` // model family if not a dilute and higher-order sum, e.g. "ECS"
+	Hardcoded    string            `json:"hardcoded"`
+	Dilute       *ViscosityDilute  `json:"dilute"`
+	Initial      *ViscosityInitial `json:"initial_density"`
+	HigherOrder  *ViscosityHigher  `json:"higher_order"`
+	SigmaEta     float64           `json:"sigma_eta"`
+	EpsilonOverK float64           `json:"epsilon_over_k"`
+
+	// ECS: reference fluid and density correction
+	ReferenceFluid string         `json:"reference_fluid"`
+	Psi            *ECSCorrection `json:"psi"`
+
+	// rhosr-CS: residual entropy scaling
+	C             float64   `json:"C"`
+	CLiq          []float64 `json:"c_liq"`
+	CVap          []float64 `json:"c_vap"`
+	RhoSrCritical float64   `json:"rhosr_critical"`
+	XCrossover    float64   `json:"x_crossover"`
+
+	// Chung and Lucas: critical constants and polarity of the estimation
+	TCritical        float64 `json:"T_critical"`
+	RhoMolarCritical float64 `json:"rhomolar_critical"`
+	Acentric         float64 `json:"acentric"`
+	DipoleMoment     float64 `json:"dipole_moment_D"` // Debye
+	Kappa            float64 `json:"kappa"`           // association factor
+	MolarMass        float64 `json:"molar_mass"`
 }
 
 type ViscosityDilute struct {
@@ -29,10 +93,26 @@ type ViscosityDilute struct {
 	A         []float64 `json:"a"`
 	MolarMass float64   `json:"molar_mass"`
 	T         []float64 `json:"t"`
+	TReducing float64   `json:"T_reducing"`
+	Hardcoded string    `json:"hardcoded"`
+}
+
+// ViscosityInitial is the initial-density viscosity term, linear in density:
+// "Rainwater-Friend" with B*(T*) = sum(b_i * T*^t_i), or "empirical" with
+// sum(n_i * delta^d_i * tau^t_i).
+type ViscosityInitial struct {
+	Type             string    `json:"type"`
+	B                []float64 `json:"b"`
+	T                []float64 `json:"t"`
+	N                []float64 `json:"n"`
+	D                []float64 `json:"d"`
+	TReducing        float64   `json:"T_reducing"`
+	RhoMolarReducing float64   `json:"rhomolar_reducing"`
 }
 
 type ViscosityHigher struct {
 	Type      string    `json:"type"`
+	Hardcoded string    `json:"hardcoded"`
 	TReduce   float64   `json:"T_reduce"`
 	RhoReduce float64   `json:"rhomolar_reduce"`
 	A         []float64 `json:"a"`
@@ -47,35 +127,95 @@ type ViscosityHigher struct {
 	L         []float64 `json:"l"`
 	P         []float64 `json:"p"`
 	Q         []float64 `json:"q"`
+
+	// friction_theory: k = (A_0 + A_1*psi1 + A_2*psi2) * tau^N for each
+	// friction coefficient, psi1 = exp(tau) - c1, psi2 = exp(tau^2) - c2
+	Ai    []float64 `json:"Ai"`
+	Aa    []float64 `json:"Aa"`
+	Ar    []float64 `json:"Ar"`
+	Aaa   []float64 `json:"Aaa"`
+	Arr   []float64 `json:"Arr"`
+	Adrdr []float64 `json:"Adrdr"`
+	Aii   []float64 `json:"Aii"`
+	Aaaa  []float64 `json:"Aaaa"`
+	Arrr  []float64 `json:"Arrr"`
+	Na    float64   `json:"Na"`
+	Naa   float64   `json:"Naa"`
+	Naaa  float64   `json:"Naaa"`
+	Nr    float64   `json:"Nr"`
+	Nrr   float64   `json:"Nrr"`
+	Nrrr  float64   `json:"Nrrr"`
+	Nii   float64   `json:"Nii"`
+	C1    float64   `json:"c1"`
+	C2    float64   `json:"c2"`
 }
 
 type ConductivityData struct {
 	BibTeX    string              `json:"BibTeX"`
+	Type      string              `json:"type"` // model family if not a dilute, residual and critical sum, e.g. "ECS"
 	Hardcoded string              `json:"hardcoded"`
 	Dilute    *ConductivityDilute `json:"dilute"`
 	Residual  *ConductivityResid  `json:"residual"`
 	Critical  *ConductivityCrit   `json:"critical"`
+
+	// ECS: reference fluid, density correction, internal-energy factor and
+	// qD of the critical enhancement
+	ReferenceFluid string         `json:"reference_fluid"`
+	Psi            *ECSCorrection `json:"psi"`
+	FInt           *ECSCorrection `json:"f_int"`
+	QD             float64        `json:"q_D"`
+}
+
+// ECSCorrection is a correction polynomial of the extended corresponding
+// states method: sum(a_i * x^t_i) with x = rho/rhomolar_reducing for psi
+// and x = T/T_reducing for f_int.
+type ECSCorrection struct {
+	A                []float64 `json:"a"`
+	T                []float64 `json:"t"`
+	RhoMolarReducing float64   `json:"rhomolar_reducing"`
+	TReducing        float64   `json:"T_reducing"`
 }
 
 type ConductivityDilute struct {
-	Type string    `json:"type"`
-	A    []float64 `json:"A"`
-	B    []float64 `json:"B"`
-	T    []float64 `json:"t"` // Sometimes used
+	Type      string    `json:"type"`
+	Hardcoded string    `json:"hardcoded"`
+	A         []float64 `json:"A"`
+	B         []float64 `json:"B"`
+	T         []float64 `json:"t"` // Sometimes used
+
+	// ratio_of_polynomials: sum(A_i * Tr^n_i) / sum(B_i * Tr^m_i)
+	N         []float64 `json:"n"`
+	M         []float64 `json:"m"`
+	TReducing float64   `json:"T_reducing"`
 }
 
 type ConductivityResid struct {
 	Type  string    `json:"type"`
 	A     []float64 `json:"A"`
+	B     []float64 `json:"B"`
 	D     []float64 `json:"d"`
 	Gamma []float64 `json:"gamma"`
 	L     []float64 `json:"l"`
 	T     []float64 `json:"t"`
+
+	// Reducing state of the "polynomial" type
+	TReducing       float64 `json:"T_reducing"`
+	RhoMassReducing float64 `json:"rhomass_reducing"`
 }
 
+// ConductivityCrit is the critical enhancement of the thermal conductivity:
+// a hardcoded term, or "simplified_Olchowy_Sengers" with its parameters.
+// Parameters missing from the file are zero and take their default values.
 type ConductivityCrit struct {
-	// Simplified for now
-	Hardcoded string `json:"hardcoded"`
+	Type      string  `json:"type"`
+	Hardcoded string  `json:"hardcoded"`
+	QD        float64 `json:"qD"`    // inverse cutoff wavelength (1/m)
+	Zeta0     float64 `json:"zeta0"` // correlation length amplitude (m)
+	GAMMA     float64 `json:"GAMMA"` // susceptibility amplitude
+	Gamma     float64 `json:"gamma"` // critical exponent of the susceptibility
+	Nu        float64 `json:"nu"`    // critical exponent of the correlation length
+	R0        float64 `json:"R0"`    // universal amplitude ratio
+	TRef      float64 `json:"T_ref"` // reference temperature (K), 1.5*Tc if zero
 }
 
 type SurfaceTensionData struct {
@@ -139,6 +279,21 @@ type Alpha0Term struct {
 	A    float64   `json:"a,omitempty"` // For LogTau
 	N    []float64 `json:"n,omitempty"`
 	T    []float64 `json:"t,omitempty"`
+
+	// PlanckEinsteinFunctionT: characteristic temperatures v (K) and Tcrit
+	V     []float64 `json:"v,omitempty"`
+	TCrit float64   `json:"Tcrit,omitempty"`
+
+	// PlanckEinsteinGeneralized: n*ln(c + d*exp(t*tau)); CP0PolyT and
+	// CP0AlyLee: cp0/R coefficients c
+	C []float64 `json:"c,omitempty"`
+	D []float64 `json:"d,omitempty"`
+
+	// CP0Constant, CP0PolyT and CP0AlyLee: reducing and reference
+	// temperatures (K) of the cp0 integration
+	CpOverR float64 `json:"cp_over_R,omitempty"`
+	Tc      float64 `json:"Tc,omitempty"`
+	T0      float64 `json:"T0,omitempty"`
 }
 
 type AlphaRTerm struct {
@@ -152,6 +307,17 @@ type AlphaRTerm struct {
 	Epsilon []float64 `json:"epsilon,omitempty"`
 	Beta    []float64 `json:"beta,omitempty"`
 	Eta     []float64 `json:"eta,omitempty"`
+	G       []float64 `json:"g,omitempty"` // For Exponential
+	M       FloatList `json:"m,omitempty"` // For Lemmon2005
+}
+
+// FloatList is a list of numbers that fluid files may also give as a single
+// number, e.g. "m", a list in Lemmon2005 terms and a number in Associating
+// terms.
+type FloatList []float64
+
+func (l *FloatList) UnmarshalJSON(data []byte) error {
+	return unmarshalModels(data, (*[]float64)(l))
 }
 
 type CriticalRegion struct {
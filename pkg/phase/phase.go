@@ -0,0 +1,125 @@
+package phase
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/saturation"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Phase identifies the phase of a pure-fluid state, following CoolProp's
+// phase names.
+type Phase int
+
+const (
+	Unknown Phase = iota
+	Liquid
+	Gas
+	TwoPhase
+	Supercritical       // T > Tc and P > Pc
+	SupercriticalGas    // T > Tc and P < Pc
+	SupercriticalLiquid // T < Tc and P > Pc
+	CriticalPoint
+)
+
+// criticalTol is the relative tolerance on T and rho for a state to be
+// reported as the critical point.
+const criticalTol = 1e-6
+
+var phaseNames = map[Phase]string{
+	Unknown:             "unknown",
+	Liquid:              "liquid",
+	Gas:                 "gas",
+	TwoPhase:            "twophase",
+	Supercritical:       "supercritical",
+	SupercriticalGas:    "supercritical_gas",
+	SupercriticalLiquid: "supercritical_liquid",
+	CriticalPoint:       "critical_point",
+}
+
+// String returns the CoolProp name of the phase, e.g. "supercritical_gas".
+func (p Phase) String() string {
+	if name, ok := phaseNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("Phase(%d)", int(p))
+}
+
+// Parse returns the Phase with the given CoolProp name.
+// The match is case-insensitive and accepts "two_phase" for TwoPhase.
+func Parse(name string) (Phase, error) {
+	name = strings.ToLower(name)
+	if name == "two_phase" {
+		return TwoPhase, nil
+	}
+	for p, n := range phaseNames {
+		if n == name {
+			return p, nil
+		}
+	}
+	return Unknown, fmt.Errorf("unknown phase '%s'", name)
+}
+
+// Classify returns the phase of the state at temperature T (K) and molar
+// density Rho (mol/m³).
+//
+// Above Tc, or when the EOS pressure exceeds Pc, the state is classified
+// against the critical point. Below Tc it is compared with the saturated
+// liquid and vapour densities at T.
+func Classify(f *fluid.FluidData, T, Rho float64) (Phase, error) {
+	if T <= 0 || Rho <= 0 {
+		return Unknown, fmt.Errorf("invalid state T=%v, Rho=%v", T, Rho)
+	}
+
+	Tc := f.States.Critical.T
+	Rhoc := f.States.Critical.RhoMolar
+	Pc := f.States.Critical.P
+
+	if math.Abs(T-Tc) <= criticalTol*Tc && math.Abs(Rho-Rhoc) <= criticalTol*Rhoc {
+		return CriticalPoint, nil
+	}
+
+	state := core.NewState(f)
+	state.Update(T, Rho)
+	P := state.Pressure()
+
+	if T >= Tc {
+		if P >= Pc {
+			return Supercritical, nil
+		}
+		return SupercriticalGas, nil
+	}
+
+	rhoL, err := saturation.RhoL(f, T)
+	if err != nil {
+		return Unknown, err
+	}
+	rhoV, err := saturation.RhoV(f, T)
+	if err != nil {
+		return Unknown, err
+	}
+
+	switch {
+	case Rho >= rhoL:
+		if P > Pc {
+			return SupercriticalLiquid, nil
+		}
+		return Liquid, nil
+	case Rho <= rhoV:
+		return Gas, nil
+	default:
+		return TwoPhase, nil
+	}
+}
+
+// ClassifyFlash returns the phase of a flash result. A quality Q in [0, 1]
+// marks a saturated state; any other Q (the flashes use -1) is classified
+// from (T, Rho).
+func ClassifyFlash(f *fluid.FluidData, T, Rho, Q float64) (Phase, error) {
+	if Q >= 0 && Q <= 1 {
+		return TwoPhase, nil
+	}
+	return Classify(f, T, Rho)
+}
@@ -0,0 +1,87 @@
+package phase
+
+import (
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/saturation"
+	"testing"
+)
+
+func TestClassify_Water(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	rhoL, _ := saturation.RhoL(f, 400)
+	rhoV, _ := saturation.RhoV(f, 400)
+
+	tests := []struct {
+		name     string
+		T, Rho   float64
+		expected Phase
+	}{
+		{"compressed liquid", 300, 55500, Liquid},
+		{"superheated vapour", 400, 0.5 * rhoV, Gas},
+		{"inside dome", 400, 0.5 * (rhoL + rhoV), TwoPhase},
+		{"supercritical", 700, 20000, Supercritical},
+		{"supercritical gas", 700, 100, SupercriticalGas},
+		{"supercritical liquid", 400, 57000, SupercriticalLiquid},
+		{"critical point", f.States.Critical.T, f.States.Critical.RhoMolar, CriticalPoint},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Classify(f, tt.T, tt.Rho)
+			if err != nil {
+				t.Fatalf("Classify(%v, %v) failed: %v", tt.T, tt.Rho, err)
+			}
+			if p != tt.expected {
+				t.Errorf("Classify(%v, %v) = %v, expected %v", tt.T, tt.Rho, p, tt.expected)
+			}
+		})
+	}
+}
+
+func TestClassifyFlash_Quality(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Nitrogen", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Nitrogen: %v", err)
+	}
+
+	// Saturated states are two-phase regardless of density
+	for _, Q := range []float64{0, 0.5, 1} {
+		p, err := ClassifyFlash(f, 77.0, 1000, Q)
+		if err != nil {
+			t.Fatalf("ClassifyFlash failed: %v", err)
+		}
+		if p != TwoPhase {
+			t.Errorf("ClassifyFlash with Q=%v = %v, expected %v", Q, p, TwoPhase)
+		}
+	}
+
+	// Q = -1 falls back to (T, Rho): nitrogen gas at 300 K, 1 atm
+	p, err := ClassifyFlash(f, 300, 40.6, -1)
+	if err != nil {
+		t.Fatalf("ClassifyFlash failed: %v", err)
+	}
+	if p != SupercriticalGas {
+		t.Errorf("ClassifyFlash(300 K, 40.6) = %v, expected %v", p, SupercriticalGas)
+	}
+}
+
+func TestParse(t *testing.T) {
+	for p, name := range phaseNames {
+		got, err := Parse(name)
+		if err != nil || got != p {
+			t.Errorf("Parse(%q) = %v, %v; expected %v", name, got, err, p)
+		}
+	}
+
+	if got, err := Parse("Two_Phase"); err != nil || got != TwoPhase {
+		t.Errorf("Parse(\"Two_Phase\") = %v, %v; expected %v", got, err, TwoPhase)
+	}
+
+	if _, err := Parse("plasma"); err == nil {
+		t.Error("Parse(\"plasma\") should fail")
+	}
+}
@@ -0,0 +1,34 @@
+package props
+
+import (
+	"GOcoolprop/pkg/flash"
+	"GOcoolprop/pkg/fluid"
+)
+
+// Error kinds returned by PropSI and PhaseSI. They are the flash package's
+// kinds, so errors.Is matches failures from either package.
+var (
+	ErrOutOfRange        = flash.ErrOutOfRange
+	ErrUnsupportedPair   = flash.ErrUnsupportedPair
+	ErrNoConvergence     = flash.ErrNoConvergence
+	ErrTwoPhaseUndefined = flash.ErrTwoPhaseUndefined
+)
+
+// Error describes a failed property call; see flash.Error.
+type Error = flash.Error
+
+// newError returns an *Error of the given kind for an input pair, wrapping
+// cause (which may be nil).
+func newError(kind error, f *fluid.FluidData, name1 string, val1 float64, name2 string, val2 float64, detail string, cause error) *Error {
+	e := &Error{
+		Kind:   kind,
+		Op:     "PropSI",
+		Inputs: []flash.Input{{Name: name1, Value: val1}, {Name: name2, Value: val2}},
+		Detail: detail,
+		Err:    cause,
+	}
+	if f != nil {
+		e.Fluid = f.Info.Name
+	}
+	return e
+}
@@ -0,0 +1,104 @@
+package props
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/flash"
+	"GOcoolprop/pkg/fluid"
+	"math"
+	"sync/atomic"
+)
+
+// RangePolicy selects how PropSI and PhaseSI handle states outside the
+// validity range of the EOS; see flash.RangePolicy.
+type RangePolicy = flash.RangePolicy
+
+const (
+	RangeError       = flash.RangeError
+	RangeWarn        = flash.RangeWarn
+	RangeExtrapolate = flash.RangeExtrapolate
+)
+
+var rangePolicy atomic.Int32
+
+// SetRangePolicy sets how PropSI and PhaseSI handle input temperatures and
+// pressures, and the temperature and pressure of the resulting state,
+// outside the validity limits of the EOS, and saturated states outside the
+// range of the saturation ancillaries. The default is RangeError, which
+// returns an ErrOutOfRange *Error naming the limit in its Limit field.
+// Warnings under RangeWarn go to flash.RangeWarning.
+func SetRangePolicy(p RangePolicy) {
+	rangePolicy.Store(int32(p))
+}
+
+// GetRangePolicy returns the policy set by SetRangePolicy.
+func GetRangePolicy() RangePolicy {
+	return RangePolicy(rangePolicy.Load())
+}
+
+// solveState resolves an input pair like solveInputs and checks the input
+// T and P, and the T and single-phase P of the result, against the validity
+// limits of the EOS.
+func solveState(f *fluid.FluidData, state *core.State, name1 string, val1 float64, name2 string, val2 float64) (T, Rho, Q float64, err error) {
+	policy := GetRangePolicy()
+
+	// ---- Inputs ----
+	Tin, Pin := math.NaN(), math.NaN()
+	for _, in := range []flash.Input{{Name: name1, Value: val1}, {Name: name2, Value: val2}} {
+		switch key, _, _ := splitImposedPhase(in.Name); key {
+		case "T":
+			Tin = in.Value
+		case "P":
+			Pin = in.Value
+		}
+	}
+	if err := checkRange(policy, f, name1, val1, name2, val2, Tin, Pin); err != nil {
+		return 0, 0, 0, err
+	}
+
+	T, Rho, Q, err = solveInputs(f, state, name1, val1, name2, val2)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	// ---- Result ----
+	// Variables given as inputs are already checked. The pressure of a
+	// mixture is the saturation pressure, which is in range with T.
+	Tout, Pout := math.NaN(), math.NaN()
+	if math.IsNaN(Tin) {
+		Tout = T
+	}
+	if math.IsNaN(Pin) && Q < 0 {
+		state.Update(T, Rho)
+		Pout = state.Pressure()
+	}
+	if err := checkRange(policy, f, name1, val1, name2, val2, Tout, Pout); err != nil {
+		return 0, 0, 0, err
+	}
+	// A saturated state must also lie within the saturation ancillaries
+	if Q >= 0 && policy != RangeExtrapolate {
+		if err := applyRange(policy, flash.CheckSaturationRange(f, T), name1, val1, name2, val2); err != nil {
+			return 0, 0, 0, err
+		}
+	}
+	return T, Rho, Q, nil
+}
+
+// checkRange applies policy to T and P (NaN to skip) of the state of an
+// input pair.
+func checkRange(policy RangePolicy, f *fluid.FluidData, name1 string, val1 float64, name2 string, val2 float64, T, P float64) error {
+	if policy == RangeExtrapolate {
+		return nil
+	}
+	return applyRange(policy, flash.CheckRange(f, T, P), name1, val1, name2, val2)
+}
+
+// applyRange sets the inputs of a range error e (which may be nil) and
+// applies policy to it.
+func applyRange(policy RangePolicy, e *flash.Error, name1 string, val1 float64, name2 string, val2 float64) error {
+	if e == nil {
+		return nil
+	}
+	e.Op = "PropSI"
+	e.Inputs = []flash.Input{{Name: name1, Value: val1}, {Name: name2, Value: val2}}
+	return flash.ApplyRangePolicy(policy, e)
+}
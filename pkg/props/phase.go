@@ -0,0 +1,29 @@
+package props
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/phase"
+)
+
+// PhaseSI returns the name of the phase of the state defined by the input
+// pair, e.g. "liquid", "gas", "twophase" or "supercritical_gas".
+// Inputs are the same as for PropSI.
+func PhaseSI(name1 string, val1 float64, name2 string, val2 float64, fluidName string) (string, error) {
+	f, err := loadFluid(fluidName)
+	if err != nil {
+		return "", err
+	}
+
+	state := core.NewState(f)
+
+	T, Rho, Q, err := solveState(f, state, name1, val1, name2, val2)
+	if err != nil {
+		return "", err
+	}
+
+	p, err := phase.ClassifyFlash(f, T, Rho, Q)
+	if err != nil {
+		return "", err
+	}
+	return p.String(), nil
+}
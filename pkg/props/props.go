@@ -4,32 +4,180 @@ import (
 	"GOcoolprop/pkg/core"
 	"GOcoolprop/pkg/flash"
 	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/phase"
 	"GOcoolprop/pkg/saturation"
 	"GOcoolprop/pkg/solver"
 	"GOcoolprop/pkg/transport"
 	"fmt"
+	"math"
 	"strings"
 )
 
 func PropSI(output, name1 string, val1 float64, name2 string, val2 float64, fluidName string) (float64, error) {
-	// Load fluid
+	f, err := loadFluid(fluidName)
+	if err != nil {
+		return 0, err
+	}
+
+	state := core.NewState(f)
+
+	// Transport outputs may ask for a saturated phase, e.g. "V|liquid"; for
+	// a single-phase state it must be the phase of the state
+	output, outputPhase, err := splitImposedPhase(output)
+	if err != nil {
+		return 0, err
+	}
+	if outputPhase != phase.Unknown && !isTransportOutput(output) {
+		return 0, fmt.Errorf("output %s: a phase can only be requested for transport outputs", output)
+	}
+
+	T, Rho, Q, err := solveState(f, state, name1, val1, name2, val2)
+	if err != nil {
+		return 0, err
+	}
+
+	// Update state with final T, Rho
+	state.Update(T, Rho)
+
+	// -------- Outputs --------
+	// Inside the dome the EOS at the mixture density is meaningless, so
+	// properties that are linear in quality come from the saturated phases.
+	if Q >= 0 && Q <= 1 {
+		if value, ok, err := twoPhaseOutput(f, state, output, T, Q); ok {
+			return value, err
+		}
+	}
+	// Transport properties of a mixture are undefined; those of its
+	// saturated phases can be requested with a phase
+	if Q >= 0 && Q <= 1 && isTransportOutput(output) {
+		if outputPhase != phase.Unknown {
+			return saturatedTransportOutput(f, output, outputPhase, T)
+		}
+		if Q > 0 && Q < 1 {
+			return 0, &Error{Kind: ErrTwoPhaseUndefined, Op: "PropSI", Fluid: f.Info.Name,
+				Inputs: []flash.Input{{Name: name1, Value: val1}, {Name: name2, Value: val2}},
+				Detail: fmt.Sprintf("output %s at Q=%v, request %s|liquid or %s|gas", output, Q, output, output)}
+		}
+	}
+	if outputPhase != phase.Unknown {
+		if err := checkOutputPhase(f, output, outputPhase, T, Rho); err != nil {
+			return 0, err
+		}
+	}
+	if Q > 0 && Q < 1 {
+		switch output {
+		case "CV", "CVMOLAR", "CP", "CPMOLAR":
+			return 0, &Error{Kind: ErrTwoPhaseUndefined, Op: "PropSI", Fluid: f.Info.Name,
+				Inputs: []flash.Input{{Name: name1, Value: val1}, {Name: name2, Value: val2}},
+				Detail: fmt.Sprintf("output %s at Q=%v", output, Q)}
+		}
+	}
+
+	switch output {
+	case "T":
+		return state.T, nil
+	case "D", "DMOLAR":
+		return state.Rho, nil
+	case "P":
+		return state.Pressure(), nil
+	case "S", "SMOLAR":
+		return state.MolarEntropy(), nil
+	case "H", "HMOLAR":
+		return state.MolarEnthalpy(), nil
+	case "U", "UMOLAR":
+		return state.MolarInternalEnergy(), nil
+	case "CV", "CVMOLAR":
+		return state.Cv(), nil
+	case "CP", "CPMOLAR":
+		return state.Cp(), nil
+	case "P_SAT":
+		return saturation.Psat(f, state.T)
+	case "T_SAT":
+		return saturation.Tsat(f, state.Pressure())
+	case "Q":
+		if Q >= 0 && Q <= 1 {
+			return Q, nil
+		}
+
+		// Quality Q = (v - vL) / (vV - vL)
+		if state.T >= f.States.Critical.T {
+			return 0, &Error{Kind: ErrOutOfRange, Op: "PropSI", Fluid: f.Info.Name,
+				Inputs: []flash.Input{{Name: "T", Value: state.T}}, Detail: "Q is undefined above Tc"}
+		}
+		rhoL, err := saturation.RhoL(f, state.T)
+		if err != nil {
+			return 0, err
+		}
+		rhoV, err := saturation.RhoV(f, state.T)
+		if err != nil {
+			return 0, err
+		}
+
+		v := 1.0 / state.Rho
+		vL := 1.0 / rhoL
+		vV := 1.0 / rhoV
+
+		return (v - vL) / (vV - vL), nil
+	case "V", "VISCOSITY":
+		return transport.Viscosity(f, state.T, state.Rho)
+	case "L", "CONDUCTIVITY":
+		return transport.Conductivity(f, state.T, state.Rho)
+	case "PRANDTL", "KINEMATIC_VISCOSITY", "THERMAL_DIFFUSIVITY":
+		tp, err := transport.Evaluate(f, state.T, state.Rho)
+		if err != nil {
+			return 0, err
+		}
+		return transportValue(tp, output), nil
+	case "I", "SURFACE_TENSION":
+		return transport.SurfaceTension(f, state.T)
+	default:
+		return 0, fmt.Errorf("output %s not supported", output)
+	}
+}
+
+// loadFluid loads a fluid from the data directory, trying the path relative
+// to the package directory when running from tests.
+func loadFluid(fluidName string) (*fluid.FluidData, error) {
 	f, err := fluid.LoadFluidByName(fluidName, "data")
 	if err != nil {
 		// Try relative path if running from tests
 		f, err = fluid.LoadFluidByName(fluidName, "../../data")
 		if err != nil {
-			return 0, fmt.Errorf("fluid not found: %v", err)
+			return nil, fmt.Errorf("fluid not found: %v", err)
 		}
 	}
+	return f, nil
+}
 
-	state := core.NewState(f)
+// solveInputs resolves an input pair to temperature, molar density and
+// vapour quality. Q is -1 for single-phase states.
+// Either input name may impose the phase with a CoolProp-style suffix, e.g.
+// "P|liquid" or "T|gas", which restricts the flash to that phase.
+func solveInputs(f *fluid.FluidData, state *core.State, name1 string, val1 float64, name2 string, val2 float64) (T, Rho, Q float64, err error) {
+	// Vapour quality of a saturated mixture; -1 for single-phase states
+	Q = -1.0
 
-	var T, Rho float64
+	// Normalize inputs and split off an imposed phase, e.g. "T|liquid"
+	name1, phase1, err := splitImposedPhase(name1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	name2, phase2, err := splitImposedPhase(name2)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	imposed := phase1
+	if imposed == phase.Unknown {
+		imposed = phase2
+	} else if phase2 != phase.Unknown && phase2 != imposed {
+		return 0, 0, 0, fmt.Errorf("conflicting imposed phases %s and %s", phase1, phase2)
+	}
 
-	// Normalize inputs
-	name1 = strings.ToUpper(name1)
-	name2 = strings.ToUpper(name2)
-	output = strings.ToUpper(output)
+	// The range is checked by solveState, on the result of any flash
+	opts := []flash.Option{flash.WithRangePolicy(flash.RangeExtrapolate)}
+	if imposed != phase.Unknown {
+		opts = append(opts, flash.WithPhase(imposed))
+	}
 
 	// -------- Input cases --------
 
@@ -55,14 +203,24 @@ func PropSI(output, name1 string, val1 float64, name2 string, val2 float64, flui
 			T = val2
 		}
 
-		// ---- Compressed-liquid shortcut ----
-		// If T < Tc and P > Psat(T), we are in compressed liquid region.
-		// The EOS struggles here, so approximate by saturated liquid density at T.
-		if T < f.States.Critical.T {
+		if imposed == phase.TwoPhase {
+			return 0, 0, 0, newError(ErrOutOfRange, f, name1, val1, name2, val2, "T and P do not define a two-phase state", nil)
+		}
+		liquidImposed := imposed == phase.Liquid || imposed == phase.SupercriticalLiquid
+		gasImposed := imposed == phase.Gas || imposed == phase.SupercriticalGas
+
+		// ---- Compressed liquid ----
+		// If T < Tc and P > Psat(T), we are in the compressed liquid region:
+		// solve on the liquid branch of the isotherm. An imposed liquid
+		// phase skips the check against Psat.
+		if T < f.States.Critical.T && liquidImposed {
+			if Rho, err = flash.FlashTPLiquid(f, T, P_target); err == nil {
+				return T, Rho, Q, nil
+			}
+		} else if T < f.States.Critical.T && imposed == phase.Unknown {
 			if PsatT, errPsat := saturation.Psat(f, T); errPsat == nil && P_target > PsatT {
-				if rhoL, err := saturation.RhoL(f, T); err == nil && rhoL > 0 {
-					Rho = rhoL
-					goto solved
+				if Rho, err = flash.FlashTPLiquid(f, T, P_target); err == nil {
+					return T, Rho, Q, nil
 				}
 			}
 		}
@@ -72,14 +230,21 @@ func PropSI(output, name1 string, val1 float64, name2 string, val2 float64, flui
 			state.Update(T, rho)
 			return state.Pressure() - P_target
 		}
+		objDerivs := func(rho float64) (float64, float64, float64) {
+			state.Update(T, rho)
+			return state.Pressure() - P_target, state.DPdRho(), state.D2PdRho2()
+		}
 
 		Pc := f.States.Critical.P
 
-		// Decide which phase to try based on Tsat(P)
-		TsatAtP, errTsat := saturation.Tsat(f, P_target)
+		// Decide which phase to try based on Tsat(P), unless it is imposed.
+		// An imposed supercritical state only uses the wide bracket.
 		tryGas := true
 		tryLiq := true
-		if errTsat == nil {
+		if imposed != phase.Unknown {
+			tryGas = gasImposed
+			tryLiq = liquidImposed
+		} else if TsatAtP, errTsat := saturation.Tsat(f, P_target); errTsat == nil {
 			if T < TsatAtP {
 				// subcooled liquid region
 				tryGas = false
@@ -92,7 +257,7 @@ func PropSI(output, name1 string, val1 float64, name2 string, val2 float64, flui
 		found := false
 
 		// ---- Gas-phase root (for low pressures) ----
-		if tryGas && P_target < 0.9*Pc {
+		if tryGas && (P_target < 0.9*Pc || gasImposed) {
 			Rg := f.EOS[0].GasConstant
 			rhoIdeal := P_target / (Rg * T)
 
@@ -106,7 +271,7 @@ func PropSI(output, name1 string, val1 float64, name2 string, val2 float64, flui
 			pMax := obj(maxRho)
 
 			if pMin*pMax < 0 {
-				if rhoGas, err := solver.Brent(obj, minRho, maxRho, 0.1); err == nil {
+				if rhoGas, err := solver.Halley(objDerivs, rhoIdeal, minRho, maxRho, 1e-12*maxRho); err == nil {
 					Rho = rhoGas
 					found = true
 				}
@@ -137,7 +302,7 @@ func PropSI(output, name1 string, val1 float64, name2 string, val2 float64, flui
 			pMax := obj(maxRho)
 
 			if pMin*pMax < 0 {
-				if rhoLiq, err := solver.Brent(obj, minRho, maxRho, 0.1); err == nil {
+				if rhoLiq, err := solver.Halley(objDerivs, rhoLGuess, minRho, maxRho, 1e-12*maxRho); err == nil {
 					Rho = rhoLiq
 					found = true
 				}
@@ -145,7 +310,7 @@ func PropSI(output, name1 string, val1 float64, name2 string, val2 float64, flui
 		}
 
 		// ---- Final fallback: wide bracket between critical and triple-liquid ----
-		if !found {
+		if !found && !gasImposed {
 			minRho := f.States.Critical.RhoMolar * 0.5
 			maxRho := f.States.TripleLiquid.RhoMolar * 1.5
 			if maxRho == 0 {
@@ -159,7 +324,7 @@ func PropSI(output, name1 string, val1 float64, name2 string, val2 float64, flui
 			pMax := obj(maxRho)
 
 			if pMin*pMax < 0 {
-				if rhoAny, err := solver.Brent(obj, minRho, maxRho, 0.1); err == nil {
+				if rhoAny, err := solver.Halley(objDerivs, 0.5*(minRho+maxRho), minRho, maxRho, 1e-12*maxRho); err == nil {
 					Rho = rhoAny
 					found = true
 				}
@@ -167,7 +332,7 @@ func PropSI(output, name1 string, val1 float64, name2 string, val2 float64, flui
 		}
 
 		if !found {
-			return 0, fmt.Errorf("no solution found for T=%v, P=%v", T, P_target)
+			return 0, 0, 0, newError(ErrNoConvergence, f, name1, val1, name2, val2, "no density found on the isotherm", nil)
 		}
 
 	} else if (name1 == "T" && name2 == "H") || (name1 == "H" && name2 == "T") {
@@ -181,9 +346,9 @@ func PropSI(output, name1 string, val1 float64, name2 string, val2 float64, flui
 			T = val2
 		}
 
-		Rho, err = flash.FlashTH(f, T, H_target)
+		Rho, err = flash.FlashTH(f, T, H_target, opts...)
 		if err != nil {
-			return 0, fmt.Errorf("T-H flash failed: %v", err)
+			return 0, 0, 0, fmt.Errorf("T-H flash failed: %w", err)
 		}
 
 	} else if (name1 == "P" && name2 == "H") || (name1 == "H" && name2 == "P") {
@@ -197,9 +362,9 @@ func PropSI(output, name1 string, val1 float64, name2 string, val2 float64, flui
 			P_target = val2
 		}
 
-		T, Rho, err = flash.FlashPH(f, P_target, H_target)
+		T, Rho, Q, err = flash.FlashPH(f, P_target, H_target, opts...)
 		if err != nil {
-			return 0, fmt.Errorf("P-H flash failed: %v", err)
+			return 0, 0, 0, fmt.Errorf("P-H flash failed: %w", err)
 		}
 
 	} else if (name1 == "P" && name2 == "S") || (name1 == "S" && name2 == "P") {
@@ -213,13 +378,61 @@ func PropSI(output, name1 string, val1 float64, name2 string, val2 float64, flui
 			P_target = val2
 		}
 
-		T, Rho, err = flash.FlashPS(f, P_target, S_target)
+		T, Rho, Q, err = flash.FlashPS(f, P_target, S_target, opts...)
 		if err != nil {
-			return 0, fmt.Errorf("P-S flash failed: %v", err)
+			return 0, 0, 0, fmt.Errorf("P-S flash failed: %w", err)
+		}
+
+	} else if (name1 == "H" && name2 == "S") || (name1 == "S" && name2 == "H") {
+		// Case 6: H and S -> solve for T and D using H-S flash
+		var H_target, S_target float64
+		if name1 == "H" {
+			H_target = val1
+			S_target = val2
+		} else {
+			S_target = val1
+			H_target = val2
+		}
+
+		T, Rho, Q, err = flash.FlashHS(f, H_target, S_target, opts...)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("H-S flash failed: %w", err)
+		}
+
+	} else if (name1 == "P" && name2 == "U") || (name1 == "U" && name2 == "P") {
+		// Case 7: P and U -> solve for T and D using P-U flash
+		var P_target, U_target float64
+		if name1 == "P" {
+			P_target = val1
+			U_target = val2
+		} else {
+			U_target = val1
+			P_target = val2
+		}
+
+		T, Rho, Q, err = flash.FlashPU(f, P_target, U_target, opts...)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("P-U flash failed: %w", err)
+		}
+
+	} else if (name1 == "T" && name2 == "U") || (name1 == "U" && name2 == "T") {
+		// Case 8: T and U -> solve for D using T-U flash
+		var U_target float64
+		if name1 == "T" {
+			T = val1
+			U_target = val2
+		} else {
+			U_target = val1
+			T = val2
+		}
+
+		Rho, Q, err = flash.FlashTU(f, T, U_target, opts...)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("T-U flash failed: %w", err)
 		}
 
 	} else if (name1 == "P" && name2 == "Q") || (name1 == "Q" && name2 == "P") {
-		// Case 6: P and Q -> saturated state at this P
+		// Case 9: P and Q -> saturated state at this P
 		var P_target, Q_target float64
 		if name1 == "P" {
 			P_target = val1
@@ -231,23 +444,29 @@ func PropSI(output, name1 string, val1 float64, name2 string, val2 float64, flui
 
 		T, err = saturation.Tsat(f, P_target)
 		if err != nil {
-			return 0, fmt.Errorf("Tsat failed: %v", err)
+			return 0, 0, 0, newError(ErrOutOfRange, f, name1, val1, name2, val2, "Tsat failed", err)
 		}
 
 		rhoL, err := saturation.RhoL(f, T)
 		if err != nil {
-			return 0, fmt.Errorf("RhoL failed: %v", err)
+			return 0, 0, 0, newError(ErrOutOfRange, f, name1, val1, name2, val2, "RhoL failed", err)
 		}
 		rhoV, err := saturation.RhoV(f, T)
 		if err != nil {
-			return 0, fmt.Errorf("RhoV failed: %v", err)
+			return 0, 0, 0, newError(ErrOutOfRange, f, name1, val1, name2, val2, "RhoV failed", err)
 		}
 
-		if Q_target <= 0 {
+		if Q_target < 0 || Q_target > 1 || math.IsNaN(Q_target) {
+			return 0, 0, 0, newError(ErrOutOfRange, f, name1, val1, name2, val2, "quality outside [0, 1]", nil)
+		}
+		if Q_target == 0 {
 			Rho = rhoL
-		} else if Q_target >= 1 {
+			Q = 0
+		} else if Q_target == 1 {
 			Rho = rhoV
+			Q = 1
 		} else {
+			Q = Q_target
 			vL := 1.0 / rhoL
 			vV := 1.0 / rhoV
 			v := Q_target*vV + (1-Q_target)*vL
@@ -255,7 +474,7 @@ func PropSI(output, name1 string, val1 float64, name2 string, val2 float64, flui
 		}
 
 	} else if (name1 == "T" && name2 == "Q") || (name1 == "Q" && name2 == "T") {
-		// Case 7: T and Q -> saturated state at this T
+		// Case 10: T and Q -> saturated state at this T
 		var Q_target float64
 		if name1 == "T" {
 			T = val1
@@ -267,80 +486,112 @@ func PropSI(output, name1 string, val1 float64, name2 string, val2 float64, flui
 
 		rhoL, err := saturation.RhoL(f, T)
 		if err != nil {
-			return 0, fmt.Errorf("RhoL failed: %v", err)
+			return 0, 0, 0, newError(ErrOutOfRange, f, name1, val1, name2, val2, "RhoL failed", err)
 		}
 		rhoV, err := saturation.RhoV(f, T)
 		if err != nil {
-			return 0, fmt.Errorf("RhoV failed: %v", err)
+			return 0, 0, 0, newError(ErrOutOfRange, f, name1, val1, name2, val2, "RhoV failed", err)
 		}
 
-		if Q_target <= 0 {
+		if Q_target < 0 || Q_target > 1 || math.IsNaN(Q_target) {
+			return 0, 0, 0, newError(ErrOutOfRange, f, name1, val1, name2, val2, "quality outside [0, 1]", nil)
+		}
+		if Q_target == 0 {
 			Rho = rhoL
-		} else if Q_target >= 1 {
+			Q = 0
+		} else if Q_target == 1 {
 			Rho = rhoV
+			Q = 1
 		} else {
+			Q = Q_target
 			vL := 1.0 / rhoL
 			vV := 1.0 / rhoV
 			v := Q_target*vV + (1-Q_target)*vL
 			Rho = 1.0 / v
 		}
 
-	} else {
-		return 0, fmt.Errorf("input pair %s, %s not supported yet", name1, name2)
-	}
+	} else if (name1 == "H" && name2 == "Q") || (name1 == "Q" && name2 == "H") {
+		// Case 11: H and Q -> saturated state with this enthalpy
+		var H_target float64
+		if name1 == "H" {
+			H_target = val1
+			Q = val2
+		} else {
+			Q = val1
+			H_target = val2
+		}
 
-solved:
-	// Update state with final T, Rho
-	state.Update(T, Rho)
+		T, Rho, err = flash.FlashHQ(f, H_target, Q)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("H-Q flash failed: %w", err)
+		}
 
-	// -------- Outputs --------
-	switch output {
-	case "T":
-		return state.T, nil
-	case "D", "DMOLAR":
-		return state.Rho, nil
-	case "P":
-		return state.Pressure(), nil
-	case "S", "SMOLAR":
-		return state.MolarEntropy(), nil
-	case "H", "HMOLAR":
-		return state.MolarEnthalpy(), nil
-	case "U", "UMOLAR":
-		return state.MolarInternalEnergy(), nil
-	case "CV", "CVMOLAR":
-		return state.Cv(), nil
-	case "CP", "CPMOLAR":
-		return state.Cp(), nil
-	case "P_SAT":
-		return saturation.Psat(f, state.T)
-	case "T_SAT":
-		return saturation.Tsat(f, state.Pressure())
-	case "Q":
-		// Quality Q = (v - vL) / (vV - vL)
-		if state.T >= f.States.Critical.T {
-			return 0, fmt.Errorf("supercritical, Q undefined")
+	} else if (name1 == "S" && name2 == "Q") || (name1 == "Q" && name2 == "S") {
+		// Case 12: S and Q -> saturated state with this entropy
+		var S_target float64
+		if name1 == "S" {
+			S_target = val1
+			Q = val2
+		} else {
+			Q = val1
+			S_target = val2
 		}
-		rhoL, err := saturation.RhoL(f, state.T)
+
+		T, Rho, err = flash.FlashSQ(f, S_target, Q)
 		if err != nil {
-			return 0, err
+			return 0, 0, 0, fmt.Errorf("S-Q flash failed: %w", err)
 		}
-		rhoV, err := saturation.RhoV(f, state.T)
+
+	} else if (name1 == "U" && name2 == "Q") || (name1 == "Q" && name2 == "U") {
+		// Case 13: U and Q -> saturated state with this internal energy
+		var U_target float64
+		if name1 == "U" {
+			U_target = val1
+			Q = val2
+		} else {
+			Q = val1
+			U_target = val2
+		}
+
+		T, Rho, err = flash.FlashUQ(f, U_target, Q)
 		if err != nil {
-			return 0, err
+			return 0, 0, 0, fmt.Errorf("U-Q flash failed: %w", err)
 		}
 
-		v := 1.0 / state.Rho
-		vL := 1.0 / rhoL
-		vV := 1.0 / rhoV
+	} else if (name1 == "D" && name2 == "Q") || (name1 == "Q" && name2 == "D") {
+		// Case 14: D and Q -> saturated state with this mixture density
+		var D_target float64
+		if name1 == "D" {
+			D_target = val1
+			Q = val2
+		} else {
+			Q = val1
+			D_target = val2
+		}
 
-		return (v - vL) / (vV - vL), nil
-	case "V", "VISCOSITY":
-		return transport.Viscosity(f, state.T, state.Rho)
-	case "L", "CONDUCTIVITY":
-		return transport.Conductivity(f, state.T, state.Rho)
-	case "I", "SURFACE_TENSION":
-		return transport.SurfaceTension(f, state.T)
-	default:
-		return 0, fmt.Errorf("output %s not supported", output)
+		T, Rho, err = flash.FlashDQ(f, D_target, Q)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("D-Q flash failed: %w", err)
+		}
+
+	} else {
+		return 0, 0, 0, newError(ErrUnsupportedPair, f, name1, val1, name2, val2, "", nil)
+	}
+
+	return T, Rho, Q, nil
+}
+
+// splitImposedPhase upper-cases an input name and splits off an optional
+// imposed phase suffix, e.g. "T|liquid".
+func splitImposedPhase(name string) (string, phase.Phase, error) {
+	key, suffix, found := strings.Cut(name, "|")
+	key = strings.ToUpper(strings.TrimSpace(key))
+	if !found {
+		return key, phase.Unknown, nil
+	}
+	p, err := phase.Parse(strings.TrimSpace(suffix))
+	if err != nil {
+		return "", phase.Unknown, fmt.Errorf("input %s: %v", name, err)
 	}
+	return key, p, nil
 }
@@ -2,6 +2,7 @@
 package props
 
 import (
+	"errors"
 	"math"
 	"testing"
 )
@@ -159,3 +160,373 @@ func TestPropSI_WaterSaturationAndQuality(t *testing.T) {
 		t.Errorf("Water quality mismatch: got %v, expected ~0.5", Q)
 	}
 }
+
+func TestPropSI_Water_HS(t *testing.T) {
+	// Superheated steam at 500 K, 1 atm: recover T and P from (H, S)
+	const (
+		T = 500.0
+		P = 101325.0
+	)
+
+	h, err := PropSI("H", "T", T, "P", P, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(H) for Water failed: %v", err)
+	}
+	s, err := PropSI("S", "T", T, "P", P, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(S) for Water failed: %v", err)
+	}
+
+	T_calc, err := PropSI("T", "H", h, "S", s, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(T) from H,S for Water failed: %v", err)
+	}
+	if math.Abs(T_calc-T) > 0.01 {
+		t.Errorf("Water H-S temperature mismatch: got %v K, expected %v K", T_calc, T)
+	}
+
+	P_calc, err := PropSI("P", "S", s, "H", h, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(P) from S,H for Water failed: %v", err)
+	}
+	if !almostEqualRel(P_calc, P, 1e-4) {
+		t.Errorf("Water H-S pressure mismatch: got %v Pa, expected %v Pa", P_calc, P)
+	}
+}
+
+func TestPropSI_Nitrogen_PU_TU(t *testing.T) {
+	// Nitrogen at 300 K, 1 atm: recover the state from U with P or T
+	const (
+		T = 300.0
+		P = 101325.0
+	)
+
+	u, err := PropSI("U", "T", T, "P", P, "Nitrogen")
+	if err != nil {
+		t.Fatalf("PropSI(U) for Nitrogen failed: %v", err)
+	}
+
+	T_calc, err := PropSI("T", "P", P, "U", u, "Nitrogen")
+	if err != nil {
+		t.Fatalf("PropSI(T) from P,U for Nitrogen failed: %v", err)
+	}
+	if math.Abs(T_calc-T) > 0.01 {
+		t.Errorf("Nitrogen P-U temperature mismatch: got %v K, expected %v K", T_calc, T)
+	}
+
+	P_calc, err := PropSI("P", "U", u, "T", T, "Nitrogen")
+	if err != nil {
+		t.Fatalf("PropSI(P) from U,T for Nitrogen failed: %v", err)
+	}
+	if !almostEqualRel(P_calc, P, 1e-3) {
+		t.Errorf("Nitrogen T-U pressure mismatch: got %v Pa, expected %v Pa", P_calc, P)
+	}
+}
+
+func TestPropSI_Water_PH_TwoPhase(t *testing.T) {
+	// Wet steam at 1 atm: H from (P, Q) must flash back to the same quality
+	const (
+		P = 101325.0
+		Q = 0.35
+	)
+
+	h, err := PropSI("H", "P", P, "Q", Q, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(H) for wet steam failed: %v", err)
+	}
+	hL, _ := PropSI("H", "P", P, "Q", 0, "Water")
+	hV, _ := PropSI("H", "P", P, "Q", 1, "Water")
+	if !almostEqualRel(h, hL+Q*(hV-hL), 1e-9) {
+		t.Errorf("Mixture enthalpy mismatch: got %v, expected %v", h, hL+Q*(hV-hL))
+	}
+
+	Q_calc, err := PropSI("Q", "P", P, "H", h, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(Q) from P,H failed: %v", err)
+	}
+	if math.Abs(Q_calc-Q) > 1e-9 {
+		t.Errorf("Quality mismatch: got %v, expected %v", Q_calc, Q)
+	}
+
+	T_calc, err := PropSI("T", "P", P, "H", h, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(T) from P,H failed: %v", err)
+	}
+	Tsat, _ := PropSI("T", "P", P, "Q", 0, "Water")
+	if math.Abs(T_calc-Tsat) > 1e-9 {
+		t.Errorf("Temperature mismatch: got %v, expected Tsat=%v", T_calc, Tsat)
+	}
+
+	P_calc, err := PropSI("P", "P", P, "H", h, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(P) from P,H failed: %v", err)
+	}
+	if !almostEqualRel(P_calc, P, 1e-6) {
+		t.Errorf("Pressure mismatch: got %v, expected %v", P_calc, P)
+	}
+}
+
+func TestPhaseSI(t *testing.T) {
+	tests := []struct {
+		name1    string
+		val1     float64
+		name2    string
+		val2     float64
+		fluid    string
+		expected string
+	}{
+		{"T", 300, "P", 101325, "Water", "liquid"},
+		{"T", 300, "Q", 0.5, "Water", "twophase"},
+		{"T", 300, "P", 101325, "Nitrogen", "supercritical_gas"},
+		{"T", 700, "D", 20000, "Water", "supercritical"},
+	}
+
+	for _, tt := range tests {
+		p, err := PhaseSI(tt.name1, tt.val1, tt.name2, tt.val2, tt.fluid)
+		if err != nil {
+			t.Fatalf("PhaseSI(%s=%v, %s=%v, %s) failed: %v", tt.name1, tt.val1, tt.name2, tt.val2, tt.fluid, err)
+		}
+		if p != tt.expected {
+			t.Errorf("PhaseSI(%s=%v, %s=%v, %s) = %s, expected %s", tt.name1, tt.val1, tt.name2, tt.val2, tt.fluid, p, tt.expected)
+		}
+	}
+}
+
+func TestPropSI_ImposedPhase(t *testing.T) {
+	// Superheated steam at 1 atm, with and without an imposed gas phase
+	D, err := PropSI("D", "T", 450, "P", 101325, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(D) failed: %v", err)
+	}
+	D_gas, err := PropSI("D", "T|gas", 450, "P", 101325, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(D) with imposed gas failed: %v", err)
+	}
+	if !almostEqualRel(D_gas, D, 1e-6) {
+		t.Errorf("Imposed gas density mismatch: got %v, expected %v", D_gas, D)
+	}
+
+	// Entropy of the steam flashes back on the gas branch
+	s, _ := PropSI("S", "T", 450, "P", 101325, "Water")
+	T, err := PropSI("T", "P", 101325, "S|gas", s, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(T) from P,S|gas failed: %v", err)
+	}
+	if math.Abs(T-450) > 1e-6 {
+		t.Errorf("Temperature mismatch: got %v, expected 450", T)
+	}
+
+	// An imposed liquid phase flashes on the liquid branch, also for
+	// superheated (metastable) liquid below Psat
+	D, err = PropSI("D", "T", 300, "P", 101325, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(D) failed: %v", err)
+	}
+	D_liq, err := PropSI("D", "T|liquid", 300, "P", 101325, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(D) with imposed liquid failed: %v", err)
+	}
+	if !almostEqualRel(D_liq, D, 1e-6) {
+		t.Errorf("Imposed liquid density mismatch: got %v, expected %v", D_liq, D)
+	}
+	DsatL, _ := PropSI("D", "T", 380, "Q", 0, "Water")
+	D_liq, err = PropSI("D", "T|liquid", 380, "P", 101325, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(D) of superheated liquid failed: %v", err)
+	}
+	if D_liq >= DsatL || !almostEqualRel(D_liq, DsatL, 1e-3) {
+		t.Errorf("Superheated liquid density %v, expected just below %v", D_liq, DsatL)
+	}
+
+	if _, err := PropSI("D", "T|twophase", 450, "P", 101325, "Water"); err == nil {
+		t.Errorf("Expected an error for an imposed two-phase T-P state")
+	}
+	if _, err := PropSI("D", "T|liquid", 450, "P|gas", 101325, "Water"); err == nil {
+		t.Errorf("Expected an error for conflicting imposed phases")
+	}
+	if _, err := PropSI("D", "T|solid", 450, "P", 101325, "Water"); err == nil {
+		t.Errorf("Expected an error for an unknown phase")
+	}
+}
+
+func TestPropSI_Water_CompressedLiquid(t *testing.T) {
+	// IAPWS-95 check value (Wagner & Pruß 2002, Table 7): 300 K, 1005.308 kg/m³
+	const M = 0.018015268 // kg/mol
+	D, err := PropSI("D", "T", 300, "P", 20.0022515e6, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(D) failed: %v", err)
+	}
+	if !almostEqualRel(D*M, 1005.308, 1e-6) {
+		t.Errorf("Compressed liquid density mismatch: got %v kg/m³, expected 1005.308", D*M)
+	}
+
+	// The density must follow the pressure, not stay at the saturated value
+	D_low, _ := PropSI("D", "T", 300, "P", 1e6, "Water")
+	if D <= D_low {
+		t.Errorf("Density at 20 MPa (%v) not above density at 1 MPa (%v)", D, D_low)
+	}
+}
+
+func TestPropSI_QualityPairs(t *testing.T) {
+	// Saturated states from (T, Q) must be recovered from (H|S|U|D, Q)
+	const (
+		T = 350.0
+		Q = 0.6
+	)
+
+	for _, name := range []string{"H", "S", "U", "D"} {
+		value, err := PropSI(name, "T", T, "Q", Q, "Water")
+		if err != nil {
+			t.Fatalf("PropSI(%s) from T,Q failed: %v", name, err)
+		}
+
+		T_calc, err := PropSI("T", name, value, "Q", Q, "Water")
+		if err != nil {
+			t.Fatalf("PropSI(T) from %s,Q failed: %v", name, err)
+		}
+		if math.Abs(T_calc-T) > 1e-6 {
+			t.Errorf("%s-Q: temperature mismatch: got %v, expected %v", name, T_calc, T)
+		}
+
+		Q_calc, err := PropSI("Q", "Q", Q, name, value, "Water")
+		if err != nil {
+			t.Fatalf("PropSI(Q) from Q,%s failed: %v", name, err)
+		}
+		if Q_calc != Q {
+			t.Errorf("%s-Q: quality mismatch: got %v, expected %v", name, Q_calc, Q)
+		}
+	}
+}
+
+func TestPropSI_Errors(t *testing.T) {
+	_, err := PropSI("H", "T", 300, "X", 1, "Water")
+	if !errors.Is(err, ErrUnsupportedPair) {
+		t.Errorf("Expected ErrUnsupportedPair, got %v", err)
+	}
+
+	_, err = PropSI("CP", "P", 101325, "Q", 0.5, "Water")
+	if !errors.Is(err, ErrTwoPhaseUndefined) {
+		t.Errorf("Expected ErrTwoPhaseUndefined, got %v", err)
+	}
+
+	// The flash error keeps its diagnostics through PropSI
+	_, err = PropSI("T", "P", 101325, "H", -1e9, "Water")
+	var e *Error
+	if !errors.Is(err, ErrNoConvergence) || !errors.As(err, &e) {
+		t.Fatalf("Expected an ErrNoConvergence *Error, got %v", err)
+	}
+	if e.Op != "FlashPH" || e.Fluid != "Water" || e.Iterations == 0 {
+		t.Errorf("Unexpected error fields: %+v", e)
+	}
+
+	_, err = PropSI("D", "T", 300, "Q", 2, "Water")
+	if !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Expected ErrOutOfRange for Q=2, got %v", err)
+	}
+}
+
+func TestPropSI_RangePolicy(t *testing.T) {
+	defer SetRangePolicy(GetRangePolicy())
+
+	_, err := PropSI("D", "T", 5000, "P", 1e5, "Water")
+	var e *Error
+	if !errors.Is(err, ErrOutOfRange) || !errors.As(err, &e) {
+		t.Fatalf("Expected an ErrOutOfRange *Error, got %v", err)
+	}
+	if e.Limit != "T_max" || e.LimitValue != 2000 {
+		t.Errorf("Unexpected limit %s=%v", e.Limit, e.LimitValue)
+	}
+
+	// The limit is also checked on the resulting state: superheated steam
+	// at 1 bar with the enthalpy of 3000 K
+	_, err = PropSI("T", "P", 1e5, "H", 160000, "Water")
+	if !errors.As(err, &e) || e.Limit != "T_max" {
+		t.Errorf("Expected a T_max error, got %v", err)
+	}
+
+	SetRangePolicy(RangeExtrapolate)
+	rho, err := PropSI("D", "T", 5000, "P", 1e5, "Water")
+	if err != nil {
+		t.Fatalf("PropSI with RangeExtrapolate failed: %v", err)
+	}
+	// Ideal gas at 5000 K
+	if rhoIdeal := 1e5 / (8.314462618 * 5000); math.Abs(rho-rhoIdeal) > 1e-3*rhoIdeal {
+		t.Errorf("D = %v, expected about %v", rho, rhoIdeal)
+	}
+}
+
+func TestPropSI_TransportGroups(t *testing.T) {
+	// Liquid water at 300 K, 1 atm: Pr ~ 5.8
+	pr, err := PropSI("PRANDTL", "T", 300.0, "P", 101325.0, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(PRANDTL) for Water failed: %v", err)
+	}
+	if !almostEqualRel(pr, 5.85, 0.02) {
+		t.Errorf("Water Prandtl number: got %v, expected ~5.85", pr)
+	}
+
+	nu, err := PropSI("KINEMATIC_VISCOSITY", "T", 300.0, "P", 101325.0, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(KINEMATIC_VISCOSITY) for Water failed: %v", err)
+	}
+	alpha, err := PropSI("THERMAL_DIFFUSIVITY", "T", 300.0, "P", 101325.0, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(THERMAL_DIFFUSIVITY) for Water failed: %v", err)
+	}
+	if !almostEqualRel(nu, 8.57e-7, 0.01) {
+		t.Errorf("Water kinematic viscosity: got %v, expected ~8.57e-7 m^2/s", nu)
+	}
+	if !almostEqualRel(pr, nu/alpha, 1e-9) {
+		t.Errorf("Prandtl number %v inconsistent with nu/alpha = %v", pr, nu/alpha)
+	}
+}
+
+func TestPropSI_TwoPhaseTransport(t *testing.T) {
+	// Transport properties of a mixture are undefined
+	_, err := PropSI("V", "T", 373.15, "Q", 0.5, "Water")
+	if !errors.Is(err, ErrTwoPhaseUndefined) {
+		t.Errorf("V at Q=0.5: expected ErrTwoPhaseUndefined, got %v", err)
+	}
+
+	// but those of the saturated phases can be requested
+	muL, err := PropSI("V|liquid", "T", 373.15, "Q", 0.5, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(V|liquid) failed: %v", err)
+	}
+	if !almostEqualRel(muL, 281.7e-6, 0.01) {
+		t.Errorf("saturated liquid viscosity: got %v, expected ~281.7e-6", muL)
+	}
+	kV, err := PropSI("L|gas", "T", 373.15, "Q", 0.5, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(L|gas) failed: %v", err)
+	}
+	if !almostEqualRel(kV, 0.02457, 0.01) {
+		t.Errorf("saturated vapour conductivity: got %v, expected ~0.02457", kV)
+	}
+
+	// The saturated states themselves are fine
+	mu0, err := PropSI("V", "T", 373.15, "Q", 0, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(V) at Q=0 failed: %v", err)
+	}
+	if !almostEqualRel(mu0, muL, 1e-6) {
+		t.Errorf("viscosity at Q=0: got %v, expected %v", mu0, muL)
+	}
+
+	// A phase is only accepted for transport outputs
+	if _, err := PropSI("H|liquid", "T", 373.15, "Q", 0.5, "Water"); err == nil {
+		t.Errorf("expected an error for H|liquid")
+	}
+
+	// and for a single-phase state it must be the phase of the state
+	if _, err := PropSI("V|liquid", "T", 400, "P", 1e5, "Water"); err == nil {
+		t.Errorf("expected an error for V|liquid of the gas")
+	}
+	muG, err := PropSI("V|gas", "T", 400, "P", 1e5, "Water")
+	if err != nil {
+		t.Fatalf("PropSI(V|gas) of the gas failed: %v", err)
+	}
+	if mu, _ := PropSI("V", "T", 400, "P", 1e5, "Water"); muG != mu {
+		t.Errorf("V|gas of the gas: got %v, expected %v", muG, mu)
+	}
+}
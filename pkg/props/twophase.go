@@ -0,0 +1,111 @@
+package props
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/phase"
+	"GOcoolprop/pkg/saturation"
+	"GOcoolprop/pkg/transport"
+	"fmt"
+)
+
+// twoPhaseOutput evaluates an output for a saturated mixture with quality Q
+// at temperature T. Pressure is the saturation pressure and the molar
+// enthalpy, entropy and internal energy are quality-weighted averages of the
+// saturated liquid and vapour values.
+// ok is false for outputs that are not handled here.
+func twoPhaseOutput(f *fluid.FluidData, state *core.State, output string, T, Q float64) (value float64, ok bool, err error) {
+	var prop func() float64
+	switch output {
+	case "P":
+		value, err = saturation.Psat(f, T)
+		return value, true, err
+	case "S", "SMOLAR":
+		prop = state.MolarEntropy
+	case "H", "HMOLAR":
+		prop = state.MolarEnthalpy
+	case "U", "UMOLAR":
+		prop = state.MolarInternalEnergy
+	default:
+		return 0, false, nil
+	}
+
+	rhoL, err := saturation.RhoL(f, T)
+	if err != nil {
+		return 0, true, err
+	}
+	rhoV, err := saturation.RhoV(f, T)
+	if err != nil {
+		return 0, true, err
+	}
+
+	state.Update(T, rhoL)
+	yL := prop()
+	state.Update(T, rhoV)
+	yV := prop()
+
+	return yL + Q*(yV-yL), true, nil
+}
+
+// isTransportOutput reports whether output is a transport property that
+// depends on the density, and so is undefined for a saturated mixture.
+func isTransportOutput(output string) bool {
+	switch output {
+	case "V", "VISCOSITY", "L", "CONDUCTIVITY", "PRANDTL", "KINEMATIC_VISCOSITY", "THERMAL_DIFFUSIVITY":
+		return true
+	}
+	return false
+}
+
+// transportValue returns the transport output of tp.
+func transportValue(tp transport.Properties, output string) float64 {
+	switch output {
+	case "V", "VISCOSITY":
+		return tp.Viscosity
+	case "L", "CONDUCTIVITY":
+		return tp.Conductivity
+	case "PRANDTL":
+		return tp.Prandtl
+	case "KINEMATIC_VISCOSITY":
+		return tp.KinematicViscosity
+	default:
+		return tp.ThermalDiffusivity
+	}
+}
+
+// saturatedTransportOutput evaluates a transport output for the saturated
+// liquid or gas at temperature T.
+func saturatedTransportOutput(f *fluid.FluidData, output string, p phase.Phase, T float64) (float64, error) {
+	var tp transport.Properties
+	var err error
+	switch p {
+	case phase.Liquid:
+		tp, err = transport.SaturatedLiquid(f, T)
+	case phase.Gas:
+		tp, err = transport.SaturatedVapor(f, T)
+	default:
+		return 0, fmt.Errorf("output %s: phase %s is not a saturated phase, use liquid or gas", output, p)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return transportValue(tp, output), nil
+}
+
+// checkOutputPhase checks that the phase p requested for a transport output
+// is that of the single-phase state (T, Rho). Liquid also matches a
+// supercritical liquid and gas a supercritical gas.
+func checkOutputPhase(f *fluid.FluidData, output string, p phase.Phase, T, Rho float64) error {
+	statePhase, err := phase.Classify(f, T, Rho)
+	if err != nil {
+		return err
+	}
+	switch {
+	case p == statePhase:
+	case p == phase.Liquid && statePhase == phase.SupercriticalLiquid:
+	case p == phase.Gas && statePhase == phase.SupercriticalGas:
+	default:
+		return fmt.Errorf("output %s|%s: the state at T=%g K, rho=%g mol/m³ is %s", output, p, T, Rho, statePhase)
+	}
+	return nil
+}
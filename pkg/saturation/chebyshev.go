@@ -0,0 +1,59 @@
+package saturation
+
+import "math"
+
+// chebNodes returns the n+1 Chebyshev-Lobatto nodes cos(πk/n) mapped onto
+// [a, b], in increasing order.
+func chebNodes(a, b float64, n int) []float64 {
+	x := make([]float64, n+1)
+	for k := 0; k <= n; k++ {
+		// k = n gives -1, i.e. the node at a
+		t := math.Cos(math.Pi * float64(n-k) / float64(n))
+		x[k] = 0.5*(a+b) + 0.5*(b-a)*t
+	}
+	x[0], x[n] = a, b
+	return x
+}
+
+// chebFit returns the coefficients of the degree-n Chebyshev interpolant of
+// the values at the nodes from chebNodes.
+func chebFit(values []float64) []float64 {
+	n := len(values) - 1
+	c := make([]float64, n+1)
+	for j := 0; j <= n; j++ {
+		sum := 0.0
+		for k := 0; k <= n; k++ {
+			// values are ordered from x = -1 (k = 0) to x = +1 (k = n)
+			term := values[k] * math.Cos(math.Pi*float64(j)*float64(n-k)/float64(n))
+			if k == 0 || k == n {
+				term *= 0.5
+			}
+			sum += term
+		}
+		c[j] = 2 * sum / float64(n)
+	}
+	c[0] *= 0.5
+	c[n] *= 0.5
+	return c
+}
+
+// chebEval evaluates Σ c_j·T_j(x) for x in [-1, 1] by Clenshaw's recurrence.
+func chebEval(c []float64, x float64) float64 {
+	b1, b2 := 0.0, 0.0
+	for j := len(c) - 1; j >= 1; j-- {
+		b1, b2 = 2*x*b1-b2+c[j], b1
+	}
+	return x*b1 - b2 + c[0]
+}
+
+// chebTail returns the size of the last two coefficients relative to the
+// largest one (or to 1 if that is smaller), a measure of the truncation
+// error of the expansion.
+func chebTail(c []float64) float64 {
+	n := len(c) - 1
+	scale := 1.0
+	for _, v := range c {
+		scale = math.Max(scale, math.Abs(v))
+	}
+	return math.Max(math.Abs(c[n]), math.Abs(c[n-1])) / scale
+}
@@ -6,8 +6,16 @@ import (
 	"fmt"
 )
 
+// The functions below use the superancillary of the fluid (see
+// GetSuperancillary) within its range, and the ancillary fits of the fluid
+// file elsewhere, e.g. just below the critical point.
+
 // Psat returns the saturation pressure at temperature T.
 func Psat(f *fluid.FluidData, T float64) (float64, error) {
+	if sa, err := GetSuperancillary(f); err == nil && sa.InRange(T) {
+		return sa.Psat(T)
+	}
+
 	// Check bounds
 	if T < f.Ancillaries.PS.TMin || T > f.Ancillaries.PS.TMax {
 		// Allow small tolerance
@@ -21,6 +29,12 @@ func Psat(f *fluid.FluidData, T float64) (float64, error) {
 
 // Tsat returns the saturation temperature at pressure P.
 func Tsat(f *fluid.FluidData, P float64) (float64, error) {
+	if sa, err := GetSuperancillary(f); err == nil {
+		if T, err := sa.Tsat(P); err == nil {
+			return T, nil
+		}
+	}
+
 	// Inverse of Psat(T) = P
 	// Objective: Psat(T) - P = 0
 
@@ -54,10 +68,16 @@ func Tsat(f *fluid.FluidData, P float64) (float64, error) {
 
 // RhoL returns the saturated liquid density at temperature T.
 func RhoL(f *fluid.FluidData, T float64) (float64, error) {
+	if sa, err := GetSuperancillary(f); err == nil && sa.InRange(T) {
+		return sa.RhoL(T)
+	}
 	return f.Ancillaries.RhoL.Evaluate(T), nil
 }
 
 // RhoV returns the saturated vapor density at temperature T.
 func RhoV(f *fluid.FluidData, T float64) (float64, error) {
+	if sa, err := GetSuperancillary(f); err == nil && sa.InRange(T) {
+		return sa.RhoV(T)
+	}
 	return f.Ancillaries.RhoV.Evaluate(T), nil
 }
@@ -0,0 +1,277 @@
+package saturation
+
+import (
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/solver"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// Superancillary holds Chebyshev expansions of the exact VLE solution of the
+// EOS: ln(psat), rhoL and ln(rhoV) as functions of T on adjacent intervals
+// covering [Tmin, Tmax]. The intervals shrink towards the critical point,
+// where the densities behave like (Tc - T)^β. Tmax lies just below Tc,
+// where the VLE solution can no longer be separated from the trivial one.
+type Superancillary struct {
+	Tmin, Tmax float64
+	intervals  []chebInterval
+}
+
+type chebInterval struct {
+	Tmin, Tmax float64
+	lnP        []float64
+	rhoL       []float64
+	lnRhoV     []float64
+}
+
+const (
+	// Degree of the expansion on each interval
+	superancillaryDegree = 16
+	// Relative size of the trailing coefficients accepted for an interval
+	superancillaryTol = 1e-12
+	// Intervals are not split below this fraction of Tc
+	superancillaryMinWidth = 1e-7
+	// Closest approach to Tc, as a fraction of Tc
+	superancillaryCritGap = 1e-6
+	// Largest tail accepted as the noise floor of the VLE solutions
+	superancillaryNoiseTol = 1e-9
+)
+
+var (
+	superancillaryMu    sync.Mutex
+	superancillaryCache = map[string]superancillaryEntry{}
+)
+
+type superancillaryEntry struct {
+	sa  *Superancillary
+	err error
+}
+
+// GetSuperancillary returns the superancillary of the fluid, building it on
+// first use. Fluids are identified by name and EOS reference, so repeated
+// loads of the same fluid share one expansion; a failed build is cached too.
+func GetSuperancillary(f *fluid.FluidData) (*Superancillary, error) {
+	if len(f.EOS) == 0 {
+		return nil, fmt.Errorf("fluid %q has no EOS", f.Info.Name)
+	}
+	key := f.Info.Name + "|" + f.EOS[0].BibTeXEOS
+
+	superancillaryMu.Lock()
+	defer superancillaryMu.Unlock()
+	if e, ok := superancillaryCache[key]; ok {
+		return e.sa, e.err
+	}
+	sa, err := BuildSuperancillary(f)
+	superancillaryCache[key] = superancillaryEntry{sa, err}
+	return sa, err
+}
+
+// BuildSuperancillary solves the VLE of the EOS at the Chebyshev nodes of a
+// set of intervals from the lower limit of the ancillaries (or the triple
+// point) up to the critical point, splitting intervals until the expansions
+// converge. The upper end stops below the first interval on which the VLE
+// could not be solved or the expansions did not converge.
+func BuildSuperancillary(f *fluid.FluidData) (*Superancillary, error) {
+	Tmin := f.Ancillaries.PS.TMin
+	if Tmin == 0 {
+		Tmin = f.States.TripleLiquid.T
+	}
+	Tc := f.EOS[0].States.Critical.T
+	if Tc == 0 {
+		Tc = f.States.Critical.T
+	}
+	if !(Tmin > 0 && Tmin < Tc) {
+		return nil, fmt.Errorf("BuildSuperancillary: invalid range [%v, %v] for %s", Tmin, Tc, f.Info.Name)
+	}
+
+	// Initial intervals halve their distance to Tc each time
+	var bounds []float64
+	for T := Tmin; Tc-T > superancillaryCritGap*Tc; T = Tc - 0.5*(Tc-T) {
+		bounds = append(bounds, T)
+	}
+	bounds = append(bounds, Tc*(1-superancillaryCritGap))
+
+	b := superancillaryBuilder{f: f, minWidth: superancillaryMinWidth * Tc}
+	sa := &Superancillary{Tmin: Tmin}
+	for i := 0; i+1 < len(bounds); i++ {
+		intervals, err := b.fit(bounds[i], bounds[i+1])
+		if err != nil {
+			// Close to Tc the VLE may fail; keep what was built below it
+			if len(sa.intervals) > 0 {
+				break
+			}
+			return nil, fmt.Errorf("BuildSuperancillary failed for %s: %v", f.Info.Name, err)
+		}
+		sa.intervals = append(sa.intervals, intervals...)
+	}
+	// Tmin may already lie within the gap left below Tc
+	if len(sa.intervals) == 0 {
+		return nil, fmt.Errorf("BuildSuperancillary: no intervals in [%v, %v] for %s", Tmin, Tc*(1-superancillaryCritGap), f.Info.Name)
+	}
+	sa.Tmax = sa.intervals[len(sa.intervals)-1].Tmax
+	return sa, nil
+}
+
+// superancillaryBuilder carries the last VLE solution, used as the
+// starting point when the ancillaries do not lead to convergence.
+type superancillaryBuilder struct {
+	f                  *fluid.FluidData
+	minWidth           float64
+	lastRhoL, lastRhoV float64
+}
+
+// fit returns the expansions for [Ta, Tb], splitting the interval in two
+// while the expansions have not converged.
+func (b *superancillaryBuilder) fit(Ta, Tb float64) ([]chebInterval, error) {
+	iv, tail, err := b.expand(Ta, Tb)
+	if err != nil {
+		return nil, err
+	}
+	return b.refine(iv, tail)
+}
+
+func (b *superancillaryBuilder) refine(iv chebInterval, tail float64) ([]chebInterval, error) {
+	if tail <= superancillaryTol {
+		return []chebInterval{iv}, nil
+	}
+	if iv.Tmax-iv.Tmin < 2*b.minWidth {
+		if tail <= superancillaryNoiseTol {
+			return []chebInterval{iv}, nil
+		}
+		return nil, fmt.Errorf("expansion did not converge on [%v, %v]", iv.Tmin, iv.Tmax)
+	}
+
+	Tm := 0.5 * (iv.Tmin + iv.Tmax)
+	lower, tailL, err := b.expand(iv.Tmin, Tm)
+	if err != nil {
+		return nil, err
+	}
+	upper, tailU, err := b.expand(Tm, iv.Tmax)
+	if err != nil {
+		return nil, err
+	}
+	// Halving a smooth interval shrinks the tail by orders of magnitude; if
+	// it does not, the tail is either the noise of the VLE solutions (close
+	// to the critical point), and splitting further cannot help, or the
+	// solutions are not smooth
+	if math.Max(tailL, tailU) > 0.25*tail {
+		if tail <= superancillaryNoiseTol {
+			return []chebInterval{iv}, nil
+		}
+		return nil, fmt.Errorf("expansion did not converge on [%v, %v]", iv.Tmin, iv.Tmax)
+	}
+
+	lowerParts, err := b.refine(lower, tailL)
+	if err != nil {
+		return nil, err
+	}
+	upperParts, err := b.refine(upper, tailU)
+	if err != nil {
+		return nil, err
+	}
+	return append(lowerParts, upperParts...), nil
+}
+
+// expand solves the VLE at the nodes of [Ta, Tb] and returns the expansions
+// with the size of their trailing coefficients.
+func (b *superancillaryBuilder) expand(Ta, Tb float64) (chebInterval, float64, error) {
+	nodes := chebNodes(Ta, Tb, superancillaryDegree)
+	lnP := make([]float64, len(nodes))
+	rhoL := make([]float64, len(nodes))
+	lnRhoV := make([]float64, len(nodes))
+	for k, T := range nodes {
+		p, rl, rv, err := b.solve(T)
+		if err != nil {
+			return chebInterval{}, 0, err
+		}
+		lnP[k], rhoL[k], lnRhoV[k] = math.Log(p), rl, math.Log(rv)
+	}
+
+	iv := chebInterval{Tmin: Ta, Tmax: Tb, lnP: chebFit(lnP), rhoL: chebFit(rhoL), lnRhoV: chebFit(lnRhoV)}
+	tail := math.Max(chebTail(iv.lnP), math.Max(chebTail(iv.rhoL), chebTail(iv.lnRhoV)))
+	return iv, tail, nil
+}
+
+func (b *superancillaryBuilder) solve(T float64) (float64, float64, float64, error) {
+	f := b.f
+	p, rhoL, rhoV, err := SolveVLE(f, T, f.Ancillaries.RhoL.Evaluate(T), f.Ancillaries.RhoV.Evaluate(T))
+	if err != nil && b.lastRhoL > 0 {
+		p, rhoL, rhoV, err = SolveVLE(f, T, b.lastRhoL, b.lastRhoV)
+	}
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	b.lastRhoL, b.lastRhoV = rhoL, rhoV
+	return p, rhoL, rhoV, nil
+}
+
+// InRange reports whether T lies within the range of the expansions.
+func (sa *Superancillary) InRange(T float64) bool {
+	return T >= sa.Tmin && T <= sa.Tmax
+}
+
+// interval returns the interval containing T and T mapped onto [-1, 1].
+func (sa *Superancillary) interval(T float64) (*chebInterval, float64, error) {
+	if !sa.InRange(T) {
+		return nil, 0, fmt.Errorf("temperature %v K out of range for the superancillary [%v, %v]", T, sa.Tmin, sa.Tmax)
+	}
+	i := sort.Search(len(sa.intervals), func(i int) bool { return sa.intervals[i].Tmax >= T })
+	iv := &sa.intervals[i]
+	return iv, (2*T - (iv.Tmin + iv.Tmax)) / (iv.Tmax - iv.Tmin), nil
+}
+
+// Psat returns the saturation pressure (Pa) at T.
+func (sa *Superancillary) Psat(T float64) (float64, error) {
+	iv, x, err := sa.interval(T)
+	if err != nil {
+		return 0, err
+	}
+	return math.Exp(chebEval(iv.lnP, x)), nil
+}
+
+// RhoL returns the saturated liquid density (mol/m³) at T.
+func (sa *Superancillary) RhoL(T float64) (float64, error) {
+	iv, x, err := sa.interval(T)
+	if err != nil {
+		return 0, err
+	}
+	return chebEval(iv.rhoL, x), nil
+}
+
+// RhoV returns the saturated vapour density (mol/m³) at T.
+func (sa *Superancillary) RhoV(T float64) (float64, error) {
+	iv, x, err := sa.interval(T)
+	if err != nil {
+		return 0, err
+	}
+	return math.Exp(chebEval(iv.lnRhoV, x)), nil
+}
+
+// Tsat returns the saturation temperature (K) at pressure P by inverting
+// the ln(psat) expansion, which increases monotonically with T.
+func (sa *Superancillary) Tsat(P float64) (float64, error) {
+	if P <= 0 {
+		return 0, fmt.Errorf("invalid pressure %v Pa", P)
+	}
+	lnP := math.Log(P)
+
+	first := &sa.intervals[0]
+	last := &sa.intervals[len(sa.intervals)-1]
+	if lnP < chebEval(first.lnP, -1) || lnP > chebEval(last.lnP, 1) {
+		return 0, fmt.Errorf("pressure %v Pa out of range for the superancillary [%v, %v]",
+			P, math.Exp(chebEval(first.lnP, -1)), math.Exp(chebEval(last.lnP, 1)))
+	}
+
+	i := sort.Search(len(sa.intervals), func(i int) bool { return chebEval(sa.intervals[i].lnP, 1) >= lnP })
+	iv := &sa.intervals[i]
+	obj := func(x float64) float64 {
+		return chebEval(iv.lnP, x) - lnP
+	}
+	x, err := solver.Brent(obj, -1, 1, 1e-15)
+	if err != nil {
+		return 0, fmt.Errorf("failed to solve for Tsat: %v", err)
+	}
+	return 0.5*(iv.Tmin+iv.Tmax) + 0.5*(iv.Tmax-iv.Tmin)*x, nil
+}
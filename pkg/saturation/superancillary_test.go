@@ -0,0 +1,143 @@
+package saturation
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"math"
+	"testing"
+)
+
+func TestSuperancillary_WaterIAPWS(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	// IAPWS-95 (Wagner & Pruss 2002), Table 8: T (K), p (Pa), rhoL and rhoV (kg/m³)
+	const M = 0.018015268
+	tests := []struct{ T, p, rhoL, rhoV float64 }{
+		{275, 698.451167, 999.887406, 0.00550664919},
+		{450, 932203.564, 890.341250, 4.81200360},
+		{625, 16908269.3, 567.090385, 118.290280},
+	}
+
+	for _, tt := range tests {
+		p, err := Psat(f, tt.T)
+		if err != nil {
+			t.Fatalf("Psat failed at %v K: %v", tt.T, err)
+		}
+		rhoL, _ := RhoL(f, tt.T)
+		rhoV, _ := RhoV(f, tt.T)
+		t.Logf("T=%v: p=%v, rhoL=%v, rhoV=%v", tt.T, p, rhoL*M, rhoV*M)
+
+		// The non-analytic terms of IAPWS-95 are not included, which shows
+		// at the 1e-7 level at 625 K
+		if math.Abs(p/tt.p-1) > 1e-6 {
+			t.Errorf("T=%v: psat %v, expected %v", tt.T, p, tt.p)
+		}
+		if math.Abs(rhoL*M/tt.rhoL-1) > 1e-6 {
+			t.Errorf("T=%v: rhoL %v, expected %v", tt.T, rhoL*M, tt.rhoL)
+		}
+		if math.Abs(rhoV*M/tt.rhoV-1) > 1e-6 {
+			t.Errorf("T=%v: rhoV %v, expected %v", tt.T, rhoV*M, tt.rhoV)
+		}
+	}
+}
+
+func TestSuperancillary_EquilibriumAndTsat(t *testing.T) {
+	for _, name := range []string{"Nitrogen", "CO2", "Propane", "Hydrogen"} {
+		f, err := fluid.LoadFluidByName(name, "../../data")
+		if err != nil {
+			t.Fatalf("Failed to load %s: %v", name, err)
+		}
+		sa, err := GetSuperancillary(f)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if sa.Tmax < f.States.Critical.T*(1-1e-4) {
+			t.Errorf("%s: superancillary ends at %v K, Tc=%v K", name, sa.Tmax, f.States.Critical.T)
+		}
+
+		state := core.NewState(f)
+		for i := 0; i < 20; i++ {
+			// Points between the Chebyshev nodes
+			T := sa.Tmin + (sa.Tmax-sa.Tmin)*(float64(i)+0.37)/20
+			p, _ := sa.Psat(T)
+			rhoL, _ := sa.RhoL(T)
+			rhoV, _ := sa.RhoV(T)
+
+			state.Update(T, rhoL)
+			PL, gL := state.Pressure(), state.Alpha+state.Delta*state.DaDDelta
+			state.Update(T, rhoV)
+			PV, gV := state.Pressure(), state.Alpha+state.Delta*state.DaDDelta
+
+			// Equal pressures, relative to the liquid's ρRT, and Gibbs energies
+			R := f.EOS[0].GasConstant
+			if math.Abs(PL-PV)/(rhoL*R*T) > 1e-10 || math.Abs(gL-gV) > 1e-10 || math.Abs(PV/p-1) > 1e-10 {
+				t.Errorf("%s T=%v: PL=%v, PV=%v, psat=%v, gL-gV=%v", name, T, PL, PV, p, gL-gV)
+			}
+
+			Ts, err := Tsat(f, p)
+			if err != nil || math.Abs(Ts-T) > 1e-9*T {
+				t.Errorf("%s: Tsat(%v) = %v (%v), expected %v", name, p, Ts, err, T)
+			}
+		}
+	}
+}
+
+func TestSuperancillary_Cached(t *testing.T) {
+	f1, _ := fluid.LoadFluidByName("Water", "../../data")
+	f2, _ := fluid.LoadFluidByName("Water", "../../data")
+	sa1, err1 := GetSuperancillary(f1)
+	sa2, err2 := GetSuperancillary(f2)
+	if err1 != nil || err2 != nil {
+		t.Fatalf("GetSuperancillary failed: %v, %v", err1, err2)
+	}
+	if sa1 != sa2 {
+		t.Errorf("Expected the superancillary to be shared between loads of the same fluid")
+	}
+
+	// Out of range
+	if _, err := sa1.Psat(sa1.Tmin - 1); err == nil {
+		t.Errorf("Expected an error below Tmin")
+	}
+	if _, err := sa1.Tsat(1e9); err == nil {
+		t.Errorf("Expected an error above the critical pressure")
+	}
+}
+
+func TestAncillary_UsingTauR(t *testing.T) {
+	// The D5 rhoV ancillary does not use Tc/T in the exponent
+	f, err := fluid.LoadFluidByName("D5", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load D5: %v", err)
+	}
+	sa, err := GetSuperancillary(f)
+	if err != nil {
+		t.Fatalf("GetSuperancillary failed: %v", err)
+	}
+	for _, T := range []float64{300, 400, 500} {
+		rhoV, _ := sa.RhoV(T)
+		anc := f.Ancillaries.RhoV.Evaluate(T)
+		if math.Abs(anc/rhoV-1) > 0.01 {
+			t.Errorf("T=%v: ancillary rhoV %v, superancillary %v", T, anc, rhoV)
+		}
+	}
+}
+
+func TestBuildSuperancillary_EmptyRange(t *testing.T) {
+	f, err := fluid.LoadFluidByName("Water", "../../data")
+	if err != nil {
+		t.Fatalf("Failed to load Water: %v", err)
+	}
+
+	// A lower limit within the gap left below Tc leaves no intervals
+	Tc := f.EOS[0].States.Critical.T
+	if Tc == 0 {
+		Tc = f.States.Critical.T
+	}
+	f.Ancillaries.PS.TMin = Tc * (1 - 1e-7)
+	if sa, err := BuildSuperancillary(f); err == nil {
+		t.Errorf("expected an error, got a superancillary on [%v, %v]", sa.Tmin, sa.Tmax)
+	}
+}
@@ -0,0 +1,70 @@
+package saturation
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/solver"
+	"fmt"
+	"math"
+)
+
+// SolveVLE solves the phase equilibrium of the EOS at temperature T: equal
+// pressure and equal molar Gibbs energy in the saturated liquid and vapour.
+// rhoL0 and rhoV0 are starting densities (mol/m³), e.g. from the
+// ancillaries. Returns Psat (Pa), rhoL and rhoV (mol/m³).
+func SolveVLE(f *fluid.FluidData, T, rhoL0, rhoV0 float64) (float64, float64, float64, error) {
+	state := core.NewState(f)
+
+	Rhoc := f.EOS[0].States.Critical.RhoMolar
+	if Rhoc == 0 {
+		Rhoc = f.States.Critical.RhoMolar
+	}
+
+	R := f.EOS[0].GasConstant
+
+	// Unknowns are the reduced densities δL and δV. Residuals:
+	// f1 = (P(δL) - P(δV)) / (ρL·R·T)
+	// f2 = g(δL)/RT - g(δV)/RT,  with g/RT = α + δ·α_δ, ∂(g/RT)/∂δ = 2α_δ + δ·α_δδ
+	// Scaling f1 by the liquid's ρRT rather than by P keeps it above the
+	// rounding noise of the liquid pressure at low temperatures.
+	gibbs := func(delta float64) (P, dPdDelta, g, dgdDelta float64) {
+		state.Update(T, delta*Rhoc)
+		P = state.Pressure()
+		dPdDelta = state.DPdRho() * Rhoc
+		g = state.Alpha + state.Delta*state.DaDDelta
+		dgdDelta = 2*state.DaDDelta + state.Delta*state.D2aDDelta2
+		return
+	}
+	funcJS := func(deltaL, deltaV float64) (f1, f2, J11, J12, J21, J22 float64) {
+		PL, dPL, gL, dgL := gibbs(deltaL)
+		PV, dPV, gV, dgV := gibbs(deltaV)
+		scale := deltaL * Rhoc * R * T
+		f1 = (PL - PV) / scale
+		f2 = gL - gV
+		J11 = dPL/scale - f1/deltaL
+		J12 = -dPV / scale
+		J21 = dgL
+		J22 = -dgV
+		return
+	}
+
+	res, err := solver.Newton2DSolve(funcJS, rhoL0/Rhoc, rhoV0/Rhoc, solver.Newton2DOptions{
+		Tol:        1e-13,
+		MaxIter:    50,
+		StepTol:    1e-15,
+		MaxRelStep: 0.5,
+		Bounds:     &solver.Bounds2D{XMax: math.Inf(1), YMax: math.Inf(1)},
+	})
+	// Near convergence the residuals can stall at the level of rounding noise
+	if err != nil && !(res.Reason == solver.LineSearchFailed && math.Abs(res.F1) < 1e-11 && math.Abs(res.F2) < 1e-11) {
+		return 0, 0, 0, fmt.Errorf("SolveVLE failed at T=%v: %v", T, err)
+	}
+
+	rhoL, rhoV := res.X*Rhoc, res.Y*Rhoc
+	// Both densities converge to the same value on the trivial solution
+	if !(rhoL > rhoV*(1+1e-6)) {
+		return 0, 0, 0, fmt.Errorf("SolveVLE converged to the trivial solution at T=%v (rho=%v)", T, rhoL)
+	}
+	state.Update(T, rhoV)
+	return state.Pressure(), rhoL, rhoV, nil
+}
@@ -0,0 +1,109 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Halley finds a root of f(x) = 0 in [a, b] starting from x0. fd returns
+// f(x), f'(x) and f”(x); steps are Halley steps, or Newton steps where
+// f” is zero or the Halley correction is unreliable.
+//
+// If f(a) and f(b) have opposite signs the bracket is kept around the root
+// and any step that leaves it, or is longer than half the previous step, is
+// replaced by bisection, so the iteration always converges. Otherwise the iterates are
+// only kept inside [a, b], stopping halfway to an end a step would cross,
+// and an error is returned if Newton's method does not converge.
+//
+// The iteration stops when a step is smaller than tol or f is exactly zero.
+func Halley(fd func(x float64) (f, df, d2f float64), x0, a, b float64, tol float64) (float64, error) {
+	const maxIter = 100
+
+	if a > b {
+		a, b = b, a
+	}
+	fa, _, _ := fd(a)
+	fb, _, _ := fd(b)
+	if fa == 0 {
+		return a, nil
+	}
+	if fb == 0 {
+		return b, nil
+	}
+	bracketed := fa*fb < 0
+
+	x := x0
+	if !(x > a && x < b) {
+		x = 0.5 * (a + b)
+	}
+	f, df, d2f := fd(x)
+	stepOld := b - a
+
+	for iter := 0; iter < maxIter; iter++ {
+		if f == 0 {
+			return x, nil
+		}
+		finite := isFinite(f)
+		if !finite && !bracketed {
+			return 0, fmt.Errorf("Halley: non-finite residual at x=%v", x)
+		}
+
+		// Tighten the bracket with the current iterate
+		if bracketed && finite {
+			if f*fa < 0 {
+				b = x
+			} else {
+				a, fa = x, f
+			}
+		}
+
+		var dx float64
+		ok := finite && df != 0 && isFinite(df)
+		if ok {
+			dx = -f / df
+			// Halley correction dx = -2ff' / (2f'² - ff''), used only if it
+			// keeps the direction of the Newton step
+			if d2f != 0 && isFinite(d2f) {
+				if denom := 2*df*df - f*d2f; denom != 0 {
+					if h := -2 * f * df / denom; h*dx > 0 {
+						dx = h
+					}
+				}
+			}
+		}
+
+		tol1 := 2.0*MachineEpsilon*math.Abs(x) + tol
+		if ok && math.Abs(dx) <= tol1 {
+			return x + dx, nil
+		}
+
+		xNew := x + dx
+		if bracketed {
+			// Bisect if the step fails, leaves the bracket or is longer
+			// than half the previous one
+			if !ok || !(xNew > a && xNew < b) || math.Abs(dx) > 0.5*math.Abs(stepOld) {
+				xNew = 0.5 * (a + b)
+			}
+		} else {
+			if !ok {
+				return 0, fmt.Errorf("Halley: zero derivative at x=%v", x)
+			}
+			if xNew <= a {
+				xNew = x - 0.5*(x-a)
+			} else if xNew >= b {
+				xNew = x + 0.5*(b-x)
+			}
+		}
+
+		if math.Abs(xNew-x) <= tol1 || (bracketed && b-a <= tol1) {
+			return xNew, nil
+		}
+
+		stepOld = xNew - x
+		x = xNew
+		f, df, d2f = fd(x)
+	}
+
+	return 0, errors.New("Halley: maximum iterations exceeded")
+}
@@ -0,0 +1,87 @@
+package solver
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHalley_Cubic(t *testing.T) {
+	// x³ - 2x - 5 = 0, root 2.0945514815423265
+	calls := 0
+	fd := func(x float64) (float64, float64, float64) {
+		calls++
+		return x*x*x - 2*x - 5, 3*x*x - 2, 6 * x
+	}
+
+	x, err := Halley(fd, 2, 1, 3, 1e-14)
+	if err != nil {
+		t.Fatalf("Halley failed: %v", err)
+	}
+	if math.Abs(x-2.0945514815423265) > 1e-13 {
+		t.Errorf("Expected 2.0945514815423265, got %v", x)
+	}
+	t.Logf("root %v in %d calls", x, calls)
+	if calls > 10 {
+		t.Errorf("Halley took %d calls, expected cubic convergence", calls)
+	}
+}
+
+func TestHalley_NewtonOnly(t *testing.T) {
+	// Without f'' the steps are Newton steps: sqrt(2)
+	fd := func(x float64) (float64, float64, float64) {
+		return x*x - 2, 2 * x, 0
+	}
+	x, err := Halley(fd, 1, 0, 2, 1e-14)
+	if err != nil {
+		t.Fatalf("Halley failed: %v", err)
+	}
+	if math.Abs(x-math.Sqrt2) > 1e-13 {
+		t.Errorf("Expected %v, got %v", math.Sqrt2, x)
+	}
+}
+
+func TestHalley_BisectionFallback(t *testing.T) {
+	// atan(x) from x0 = 5: plain Newton diverges, the bracket keeps it safe
+	fd := func(x float64) (float64, float64, float64) {
+		return math.Atan(x), 1 / (1 + x*x), -2 * x / ((1 + x*x) * (1 + x*x))
+	}
+	x, err := Halley(fd, 5, -3, 10, 1e-12)
+	if err != nil {
+		t.Fatalf("Halley failed: %v", err)
+	}
+	if math.Abs(x) > 1e-10 {
+		t.Errorf("Expected 0, got %v", x)
+	}
+
+	// A zero derivative inside the bracket also falls back to bisection
+	fd = func(x float64) (float64, float64, float64) {
+		return (x - 1) * (x - 1) * (x - 1), 3 * (x - 1) * (x - 1), 6 * (x - 1)
+	}
+	x, err = Halley(fd, 1, 0, 3, 1e-12)
+	if err != nil {
+		t.Fatalf("Halley failed: %v", err)
+	}
+	if math.Abs(x-1) > 1e-4 {
+		t.Errorf("Expected 1, got %v", x)
+	}
+}
+
+func TestHalley_Unbracketed(t *testing.T) {
+	// x² - 2 on [0.5, 1.2]: no sign change, the root lies outside; the
+	// iterates must stay in the interval and the call must fail
+	fd := func(x float64) (float64, float64, float64) {
+		return x*x - 2, 2 * x, 2
+	}
+	if x, err := Halley(fd, 1, 0.5, 1.2, 1e-12); err == nil && (x < 0.5 || x > 1.2) {
+		t.Errorf("Iterate %v left the interval", x)
+	}
+
+	// Both ends positive with roots inside: converges like Newton
+	x, err := Halley(fd, 3, -10, 10, 1e-12)
+	if err != nil {
+		t.Fatalf("Halley failed: %v", err)
+	}
+	if math.Abs(x-math.Sqrt2) > 1e-12 {
+		t.Errorf("Expected %v, got %v", math.Sqrt2, x)
+	}
+}
@@ -0,0 +1,150 @@
+package solver
+
+import (
+	"fmt"
+	"math"
+)
+
+// LMOptions configures LevenbergMarquardt. A zero tolerance disables that
+// test.
+type LMOptions struct {
+	Tol     float64 // stop when every |f_i| < Tol (zero-residual problems)
+	GradTol float64 // stop when every |(Jᵀf)_j| < GradTol
+	StepTol float64 // stop when every relative step |Δx_j| <= StepTol·|x_j|
+	MaxIter int     // maximum number of accepted steps, at least 0
+
+	// Lambda0 is the initial damping; 1e-3 if zero.
+	Lambda0 float64
+}
+
+// LMResult holds the final iterate and convergence diagnostics.
+type LMResult struct {
+	X          []float64  // final iterate
+	F          []float64  // residuals at X
+	Cost       float64    // ½·Σ f_i²
+	Iterations int        // accepted steps
+	Reason     StopReason // why the iteration stopped
+}
+
+// LevenbergMarquardt minimises ½·Σ f_i(x)² for m >= n residuals in n
+// unknowns. Each step solves (JᵀJ + λ·diag(JᵀJ))·Δx = -Jᵀf; λ is reduced
+// after a step that lowers the cost and increased until one does.
+// It also solves square systems from starting points where Newton's method
+// fails, at the price of slower convergence.
+// The returned result is filled in even when err is non-nil.
+func LevenbergMarquardt(fj ResidualJacobianFunc, x0 []float64, opts LMOptions) (LMResult, error) {
+	const (
+		lambdaUp   = 10.0
+		lambdaDown = 10.0
+		lambdaMax  = 1e16
+	)
+
+	n := len(x0)
+	x := append([]float64(nil), x0...)
+	if opts.MaxIter < 0 {
+		return LMResult{X: x, Reason: InvalidOptions}, fmt.Errorf("negative MaxIter %d", opts.MaxIter)
+	}
+	f, J := fj(x)
+	if len(f) < n {
+		return LMResult{X: x, F: f}, fmt.Errorf("LevenbergMarquardt: %d residuals for %d unknowns", len(f), n)
+	}
+
+	lambda := opts.Lambda0
+	if lambda <= 0 {
+		lambda = 1e-3
+	}
+
+	res := LMResult{X: x, F: f, Cost: halfSquaredNorm(f)}
+
+	for i := 0; ; i++ {
+		res.Iterations = i
+
+		if !allFinite(f) {
+			res.Reason = NonFinite
+			return res, fmt.Errorf("non-finite residuals at iter %d (x=%v)", i, x)
+		}
+		if opts.Tol > 0 && maxAbs(f) < opts.Tol {
+			res.Reason = Converged
+			return res, nil
+		}
+
+		// Normal equations: A = JᵀJ, g = Jᵀf
+		A := make([][]float64, n)
+		g := make([]float64, n)
+		for j := 0; j < n; j++ {
+			A[j] = make([]float64, n)
+			for k := 0; k < n; k++ {
+				for r := range f {
+					A[j][k] += J[r][j] * J[r][k]
+				}
+			}
+			for r := range f {
+				g[j] += J[r][j] * f[r]
+			}
+		}
+
+		if opts.GradTol > 0 && maxAbs(g) < opts.GradTol {
+			res.Reason = ConvergedGradient
+			return res, nil
+		}
+
+		if i == opts.MaxIter {
+			res.Reason = MaxIterations
+			return res, fmt.Errorf("max iterations (%d) reached without convergence (x=%v, cost=%v)", opts.MaxIter, x, res.Cost)
+		}
+
+		// Increase the damping until a step lowers the cost
+		accepted := false
+		for lambda <= lambdaMax {
+			M := make([][]float64, n)
+			minusG := make([]float64, n)
+			for j := 0; j < n; j++ {
+				M[j] = append([]float64(nil), A[j]...)
+				// Marquardt scaling by the diagonal, kept positive for
+				// unknowns that do not yet affect the residuals
+				M[j][j] += lambda * math.Max(A[j][j], 1e-12)
+				minusG[j] = -g[j]
+			}
+
+			dx, err := SolveLinear(M, minusG)
+			if err != nil {
+				lambda *= lambdaUp
+				continue
+			}
+
+			xt := make([]float64, n)
+			for j := range x {
+				xt[j] = x[j] + dx[j]
+			}
+			ft, Jt := fj(xt)
+			if cost := halfSquaredNorm(ft); allFinite(ft) && cost < res.Cost {
+				small := opts.StepTol > 0 && smallStep(x, dx, opts.StepTol)
+				x, f, J = xt, ft, Jt
+				res.X, res.F, res.Cost = x, f, cost
+				lambda = math.Max(lambda/lambdaDown, 1e-12)
+				accepted = true
+
+				if small {
+					res.Iterations = i + 1
+					res.Reason = ConvergedStep
+					return res, nil
+				}
+				break
+			}
+			lambda *= lambdaUp
+		}
+
+		if !accepted {
+			res.Reason = LineSearchFailed
+			return res, fmt.Errorf("no step reduced the cost at iter %d (x=%v, cost=%v)", i, x, res.Cost)
+		}
+	}
+}
+
+func halfSquaredNorm(f []float64) float64 {
+	sum := 0.0
+	for _, v := range f {
+		sum += v * v
+	}
+	return 0.5 * sum
+}
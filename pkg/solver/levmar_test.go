@@ -0,0 +1,83 @@
+package solver
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLevenbergMarquardt_ExponentialFit(t *testing.T) {
+	// Fit y = a·exp(b·t) to exact data with a = 2, b = -0.5, using a
+	// finite-difference Jacobian
+	ts := []float64{0, 0.5, 1, 1.5, 2, 3, 4, 5}
+	ys := make([]float64, len(ts))
+	for i, ti := range ts {
+		ys[i] = 2 * math.Exp(-0.5*ti)
+	}
+	f := func(p []float64) []float64 {
+		r := make([]float64, len(ts))
+		for i, ti := range ts {
+			r[i] = p[0]*math.Exp(p[1]*ti) - ys[i]
+		}
+		return r
+	}
+
+	res, err := LevenbergMarquardt(FiniteDifferenceJacobian(f), []float64{1, 0}, LMOptions{Tol: 1e-10, MaxIter: 100})
+	if err != nil {
+		t.Fatalf("LevenbergMarquardt failed: %v (reason %v)", err, res.Reason)
+	}
+	t.Logf("a=%v, b=%v after %d iterations: %v", res.X[0], res.X[1], res.Iterations, res.Reason)
+
+	if math.Abs(res.X[0]-2) > 1e-8 || math.Abs(res.X[1]+0.5) > 1e-8 {
+		t.Errorf("Expected (2, -0.5), got %v", res.X)
+	}
+}
+
+func TestLevenbergMarquardt_LinearLeastSquares(t *testing.T) {
+	// Straight line through points that are not collinear: the minimum has
+	// non-zero residuals, so the iteration stops on the gradient
+	ts := []float64{0, 1, 2, 3}
+	ys := []float64{1, 3, 2, 5}
+	fj := func(p []float64) ([]float64, [][]float64) {
+		f := make([]float64, len(ts))
+		J := make([][]float64, len(ts))
+		for i, ti := range ts {
+			f[i] = p[0] + p[1]*ti - ys[i]
+			J[i] = []float64{1, ti}
+		}
+		return f, J
+	}
+
+	res, err := LevenbergMarquardt(fj, []float64{0, 0}, LMOptions{GradTol: 1e-10, MaxIter: 100})
+	if err != nil {
+		t.Fatalf("LevenbergMarquardt failed: %v (reason %v)", err, res.Reason)
+	}
+
+	// Normal equations give intercept 1.1 and slope 1.1
+	if math.Abs(res.X[0]-1.1) > 1e-8 || math.Abs(res.X[1]-1.1) > 1e-8 {
+		t.Errorf("Expected (1.1, 1.1), got %v", res.X)
+	}
+	if res.Reason != ConvergedGradient {
+		t.Errorf("Expected convergence on the gradient, got %v", res.Reason)
+	}
+	// Residuals (-0.1, 0.8, -1.3, 0.6)
+	if math.Abs(res.Cost-0.5*2.7) > 1e-10 {
+		t.Errorf("Cost %v, expected %v", res.Cost, 0.5*2.7)
+	}
+}
+
+func TestLevenbergMarquardt_Rosenbrock(t *testing.T) {
+	res, err := LevenbergMarquardt(rosenbrock, []float64{-1.2, 1}, LMOptions{Tol: 1e-12, MaxIter: 200})
+	if err != nil {
+		t.Fatalf("LevenbergMarquardt failed: %v (reason %v)", err, res.Reason)
+	}
+	if math.Abs(res.X[0]-1) > 1e-10 || math.Abs(res.X[1]-1) > 1e-10 {
+		t.Errorf("Expected (1, 1), got %v", res.X)
+	}
+}
+
+func TestLevenbergMarquardt_NegativeMaxIter(t *testing.T) {
+	res, err := LevenbergMarquardt(rosenbrock, []float64{-1.2, 1}, LMOptions{Tol: 1e-12, MaxIter: -1})
+	if err == nil || res.Reason != InvalidOptions || res.Iterations != 0 {
+		t.Errorf("Expected invalid options, got reason %v after %d, err %v", res.Reason, res.Iterations, err)
+	}
+}
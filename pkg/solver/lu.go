@@ -0,0 +1,97 @@
+package solver
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrSingular is returned when a matrix is singular to working precision.
+var ErrSingular = errors.New("singular matrix")
+
+// LU is the LU factorisation with partial pivoting of a square matrix,
+// P·A = L·U, stored compactly: U on and above the diagonal, L (with unit
+// diagonal) below it.
+type LU struct {
+	lu   [][]float64
+	perm []int
+}
+
+// LUFactor factorises the n×n matrix A. A is not modified.
+func LUFactor(A [][]float64) (*LU, error) {
+	n := len(A)
+	lu := make([][]float64, n)
+	perm := make([]int, n)
+	scale := 0.0
+	for i := range A {
+		if len(A[i]) != n {
+			return nil, errors.New("LUFactor: matrix is not square")
+		}
+		lu[i] = append([]float64(nil), A[i]...)
+		perm[i] = i
+		for _, v := range A[i] {
+			scale = math.Max(scale, math.Abs(v))
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		// Partial pivoting: largest entry in column k
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(lu[i][k]) > math.Abs(lu[p][k]) {
+				p = i
+			}
+		}
+		if math.Abs(lu[p][k]) <= float64(n)*MachineEpsilon*scale || scale == 0 {
+			return nil, ErrSingular
+		}
+		lu[k], lu[p] = lu[p], lu[k]
+		perm[k], perm[p] = perm[p], perm[k]
+
+		for i := k + 1; i < n; i++ {
+			lu[i][k] /= lu[k][k]
+			for j := k + 1; j < n; j++ {
+				lu[i][j] -= lu[i][k] * lu[k][j]
+			}
+		}
+	}
+
+	return &LU{lu: lu, perm: perm}, nil
+}
+
+// Solve returns x with A·x = b.
+func (f *LU) Solve(b []float64) []float64 {
+	n := len(f.lu)
+	x := make([]float64, n)
+
+	// Forward substitution with L on the permuted right-hand side
+	for i := 0; i < n; i++ {
+		sum := b[f.perm[i]]
+		for j := 0; j < i; j++ {
+			sum -= f.lu[i][j] * x[j]
+		}
+		x[i] = sum
+	}
+
+	// Back substitution with U
+	for i := n - 1; i >= 0; i-- {
+		sum := x[i]
+		for j := i + 1; j < n; j++ {
+			sum -= f.lu[i][j] * x[j]
+		}
+		x[i] = sum / f.lu[i][i]
+	}
+
+	return x
+}
+
+// SolveLinear solves the n×n system A·x = b by LU factorisation.
+func SolveLinear(A [][]float64, b []float64) ([]float64, error) {
+	if len(b) != len(A) {
+		return nil, errors.New("SolveLinear: dimension mismatch")
+	}
+	f, err := LUFactor(A)
+	if err != nil {
+		return nil, err
+	}
+	return f.Solve(b), nil
+}
@@ -0,0 +1,42 @@
+package solver
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSolveLinear(t *testing.T) {
+	// Needs pivoting: the leading entry is zero
+	A := [][]float64{
+		{0, 2, 1},
+		{1, 1, 1},
+		{2, 1, 3},
+	}
+	b := []float64{7, 6, 13}
+	// Solution: x = (1, 2, 3)
+
+	x, err := SolveLinear(A, b)
+	if err != nil {
+		t.Fatalf("SolveLinear failed: %v", err)
+	}
+	for i, expected := range []float64{1, 2, 3} {
+		if math.Abs(x[i]-expected) > 1e-12 {
+			t.Errorf("x[%d] = %v, expected %v", i, x[i], expected)
+		}
+	}
+
+	// A must not be modified
+	if A[0][0] != 0 || A[2][2] != 3 {
+		t.Errorf("SolveLinear modified its input: %v", A)
+	}
+}
+
+func TestLUFactor_Singular(t *testing.T) {
+	A := [][]float64{
+		{1, 2},
+		{2, 4},
+	}
+	if _, err := LUFactor(A); err != ErrSingular {
+		t.Errorf("Expected ErrSingular, got %v", err)
+	}
+}
@@ -5,6 +5,71 @@ import (
 	"math"
 )
 
+// StopReason tells why an iterative solver stopped.
+type StopReason int
+
+const (
+	Converged         StopReason = iota // residuals below tolerance
+	ConvergedStep                       // step below the step tolerance
+	MaxIterations                       // iteration limit reached
+	SingularJacobian                    // Jacobian determinant vanished
+	LineSearchFailed                    // no step length reduced the residual
+	NonFinite                           // residuals were NaN or Inf
+	ConvergedGradient                   // least-squares gradient below tolerance
+	InvalidOptions                      // solver options were invalid
+)
+
+var stopReasonNames = map[StopReason]string{
+	Converged:         "converged",
+	ConvergedStep:     "converged on step size",
+	MaxIterations:     "max iterations reached",
+	SingularJacobian:  "singular Jacobian",
+	LineSearchFailed:  "line search failed",
+	NonFinite:         "non-finite residuals",
+	ConvergedGradient: "converged on gradient",
+	InvalidOptions:    "invalid options",
+}
+
+func (r StopReason) String() string {
+	if name, ok := stopReasonNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("StopReason(%d)", int(r))
+}
+
+// Bounds2D is a box constraint on (x, y). Use ±Inf for an open side.
+type Bounds2D struct {
+	XMin, XMax float64
+	YMin, YMax float64
+}
+
+// Newton2DOptions configures Newton2DSolve.
+type Newton2DOptions struct {
+	Tol     float64 // convergence tolerance on |f1| and |f2|
+	MaxIter int     // maximum number of Newton steps, at least 0
+
+	// StepTol, if positive, also accepts convergence when the relative step
+	// in both x and y drops below it, i.e. the residuals are at the level of
+	// rounding noise.
+	StepTol float64
+
+	// MaxRelStep, if positive, limits each step to |Δx| <= MaxRelStep·|x|
+	// and |Δy| <= MaxRelStep·|y|, scaling both components together.
+	MaxRelStep float64
+
+	// Bounds, if set, keeps the iterates inside the box: a step that would
+	// leave it is shortened to stop halfway to the boundary.
+	Bounds *Bounds2D
+}
+
+// Newton2DResult holds the final iterate and convergence diagnostics.
+type Newton2DResult struct {
+	X, Y       float64    // final iterate
+	F1, F2     float64    // residuals at (X, Y)
+	Iterations int        // Newton steps taken
+	Reason     StopReason // why the iteration stopped
+}
+
 // Newton2D solves a system of 2 equations with 2 unknowns using Newton-Raphson method.
 // funcJS returns the residuals (f1, f2) and the Jacobian matrix elements (J11, J12, J21, J22)
 // at a given point (x, y).
@@ -21,22 +86,60 @@ import (
 // The update step is:
 // [ Δx ] = -J^-1 * [ f1 ]
 // [ Δy ]           [ f2 ]
+//
+// Steps are damped by a backtracking line search; see Newton2DSolve for
+// bounds, step limits and diagnostics.
 func Newton2D(funcJS func(x, y float64) (f1, f2, J11, J12, J21, J22 float64), x0, y0 float64, tol float64, maxIter int) (x, y float64, err error) {
-	x = x0
-	y = y0
+	res, err := Newton2DSolve(funcJS, x0, y0, Newton2DOptions{Tol: tol, MaxIter: maxIter})
+	return res.X, res.Y, err
+}
+
+// Newton2DSolve is Newton2D with a backtracking line search on the scaled
+// residual norm, optional box bounds and step limiter, and convergence
+// diagnostics. The returned result is filled in even when err is non-nil.
+func Newton2DSolve(funcJS func(x, y float64) (f1, f2, J11, J12, J21, J22 float64), x0, y0 float64, opts Newton2DOptions) (Newton2DResult, error) {
+	// Armijo constant and maximum number of step halvings
+	const (
+		armijo        = 1e-4
+		maxBacktracks = 30
+	)
+
+	if opts.MaxIter < 0 {
+		return Newton2DResult{X: x0, Y: y0, Reason: InvalidOptions}, fmt.Errorf("negative MaxIter %d", opts.MaxIter)
+	}
+
+	x, y := x0, y0
+	if opts.Bounds != nil {
+		x, y = opts.Bounds.clamp(x, y)
+	}
 
-	for i := 0; i < maxIter; i++ {
-		f1, f2, J11, J12, J21, J22 := funcJS(x, y)
+	f1, f2, J11, J12, J21, J22 := funcJS(x, y)
+	res := Newton2DResult{X: x, Y: y, F1: f1, F2: f2}
+
+	for i := 0; ; i++ {
+		res.Iterations = i
+
+		if !isFinite(f1) || !isFinite(f2) {
+			res.Reason = NonFinite
+			return res, fmt.Errorf("non-finite residuals at iter %d (x=%v, y=%v)", i, x, y)
+		}
 
 		// Check convergence on residuals
-		if math.Abs(f1) < tol && math.Abs(f2) < tol {
-			return x, y, nil
+		if math.Abs(f1) < opts.Tol && math.Abs(f2) < opts.Tol {
+			res.Reason = Converged
+			return res, nil
+		}
+
+		if i == opts.MaxIter {
+			res.Reason = MaxIterations
+			return res, fmt.Errorf("max iterations (%d) reached without convergence (x=%v, y=%v, f1=%v, f2=%v)", opts.MaxIter, x, y, f1, f2)
 		}
 
 		// Calculate determinant
 		det := J11*J22 - J12*J21
-		if math.Abs(det) < 1e-20 {
-			return x, y, fmt.Errorf("singular Jacobian at iter %d (x=%v, y=%v)", i, x, y)
+		if math.Abs(det) < 1e-20 || math.IsNaN(det) {
+			res.Reason = SingularJacobian
+			return res, fmt.Errorf("singular Jacobian at iter %d (x=%v, y=%v)", i, x, y)
 		}
 
 		// Calculate inverse Jacobian * residuals
@@ -46,16 +149,100 @@ func Newton2D(funcJS func(x, y float64) (f1, f2, J11, J12, J21, J22 float64), x0
 		dx := -(J22*f1 - J12*f2) / det
 		dy := -(-J21*f1 + J11*f2) / det
 
-		// Limit step size if needed (damping could be added here)
+		// Limit the step size, keeping its direction
+		if opts.MaxRelStep > 0 {
+			scale := 1.0
+			if limit := opts.MaxRelStep * math.Abs(x); math.Abs(dx) > limit && limit > 0 {
+				scale = math.Min(scale, limit/math.Abs(dx))
+			}
+			if limit := opts.MaxRelStep * math.Abs(y); math.Abs(dy) > limit && limit > 0 {
+				scale = math.Min(scale, limit/math.Abs(dy))
+			}
+			dx *= scale
+			dy *= scale
+		}
+
+		// Stay inside the box
+		if opts.Bounds != nil {
+			scale := opts.Bounds.stepFraction(x, y, dx, dy)
+			dx *= scale
+			dy *= scale
+		}
+
+		// A step at the level of rounding noise cannot improve the residuals
+		if opts.StepTol > 0 && math.Abs(dx) <= opts.StepTol*math.Abs(x) && math.Abs(dy) <= opts.StepTol*math.Abs(y) {
+			res.X, res.Y = x+dx, y+dy
+			res.Reason = ConvergedStep
+			return res, nil
+		}
+
+		// Backtracking line search: halve the step until the residual norm
+		// decreases sufficiently (Armijo condition). Each residual is scaled
+		// by its sensitivity |J_i1|·|x| + |J_i2|·|y|, held fixed during the
+		// search, so that equations in different units weigh alike.
+		s1 := residualScale(J11, J12, x, y)
+		s2 := residualScale(J21, J22, x, y)
+		norm := func(f1, f2 float64) float64 {
+			return (f1/s1)*(f1/s1) + (f2/s2)*(f2/s2)
+		}
+
+		norm0 := norm(f1, f2)
+		lambda := 1.0
+		accepted := false
+		for k := 0; k <= maxBacktracks; k++ {
+			xt, yt := x+lambda*dx, y+lambda*dy
+			g1, g2, K11, K12, K21, K22 := funcJS(xt, yt)
+			if isFinite(g1) && isFinite(g2) && norm(g1, g2) <= (1-2*armijo*lambda)*norm0 {
+				x, y = xt, yt
+				f1, f2, J11, J12, J21, J22 = g1, g2, K11, K12, K21, K22
+				accepted = true
+				break
+			}
+			lambda *= 0.5
+		}
+
+		res.X, res.Y, res.F1, res.F2 = x, y, f1, f2
+		if !accepted {
+			res.Iterations = i + 1
+			res.Reason = LineSearchFailed
+			return res, fmt.Errorf("line search failed at iter %d (x=%v, y=%v, f1=%v, f2=%v)", i, x, y, f1, f2)
+		}
+	}
+}
 
-		x += dx
-		y += dy
+// clamp moves (x, y) into the box.
+func (b *Bounds2D) clamp(x, y float64) (float64, float64) {
+	return math.Min(math.Max(x, b.XMin), b.XMax), math.Min(math.Max(y, b.YMin), b.YMax)
+}
 
-		// Check for NaN/Inf
-		if math.IsNaN(x) || math.IsNaN(y) || math.IsInf(x, 0) || math.IsInf(y, 0) {
-			return x, y, fmt.Errorf("solver diverged to NaN/Inf at iter %d", i)
+// stepFraction returns the largest fraction (at most 1) of the step (dx, dy)
+// that keeps (x, y) inside the box, stopping halfway to any boundary the
+// full step would cross.
+func (b *Bounds2D) stepFraction(x, y, dx, dy float64) float64 {
+	frac := 1.0
+	limit := func(v, dv, lo, hi float64) {
+		if v+dv < lo && dv < 0 {
+			frac = math.Min(frac, 0.5*(lo-v)/dv)
+		}
+		if v+dv > hi && dv > 0 {
+			frac = math.Min(frac, 0.5*(hi-v)/dv)
 		}
 	}
+	limit(x, dx, b.XMin, b.XMax)
+	limit(y, dy, b.YMin, b.YMax)
+	return frac
+}
+
+// residualScale returns |Ja|·max(|x|, 1) + |Jb|·max(|y|, 1) for a Jacobian
+// row (Ja, Jb), or 1 if that vanishes.
+func residualScale(Ja, Jb, x, y float64) float64 {
+	s := math.Abs(Ja)*math.Max(math.Abs(x), 1) + math.Abs(Jb)*math.Max(math.Abs(y), 1)
+	if s == 0 || !isFinite(s) {
+		return 1
+	}
+	return s
+}
 
-	return x, y, fmt.Errorf("max iterations (%d) reached without convergence", maxIter)
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
 }
@@ -62,3 +62,115 @@ func TestNewton2D_NonLinear(t *testing.T) {
 		t.Errorf("Expected (%v, %v), got (%v, %v)", expected, expected, x, y)
 	}
 }
+
+func TestNewton2DSolve_LineSearch(t *testing.T) {
+	// atan(x) = 0 diverges under full Newton steps from |x0| > 1.39
+	funcJS := func(x, y float64) (f1, f2, J11, J12, J21, J22 float64) {
+		f1 = math.Atan(x)
+		f2 = y - 1
+		J11 = 1 / (1 + x*x)
+		J22 = 1
+		return
+	}
+
+	res, err := Newton2DSolve(funcJS, 3, 0, Newton2DOptions{Tol: 1e-12, MaxIter: 50})
+	if err != nil {
+		t.Fatalf("Newton2DSolve failed: %v (reason %v)", err, res.Reason)
+	}
+	t.Logf("Converged to (%v, %v) in %d iterations", res.X, res.Y, res.Iterations)
+
+	if math.Abs(res.X) > 1e-9 || math.Abs(res.Y-1) > 1e-9 {
+		t.Errorf("Expected (0, 1), got (%v, %v)", res.X, res.Y)
+	}
+	if res.Reason != Converged || math.Abs(res.F1) >= 1e-12 || math.Abs(res.F2) >= 1e-12 {
+		t.Errorf("Unexpected diagnostics: %+v", res)
+	}
+}
+
+func TestNewton2DSolve_Bounds(t *testing.T) {
+	// x² - 4 = 0 and y = x: from (0.5, 0.5) the first full step overshoots
+	// to x = 4.25, past the upper bound
+	funcJS := func(x, y float64) (f1, f2, J11, J12, J21, J22 float64) {
+		f1 = x*x - 4
+		f2 = y - x
+		J11 = 2 * x
+		J21 = -1
+		J22 = 1
+		return
+	}
+
+	bounds := &Bounds2D{XMin: 0, XMax: 3, YMin: math.Inf(-1), YMax: math.Inf(1)}
+	visited := 0.0
+	tracked := func(x, y float64) (f1, f2, J11, J12, J21, J22 float64) {
+		visited = math.Max(visited, x)
+		return funcJS(x, y)
+	}
+
+	res, err := Newton2DSolve(tracked, 0.5, 0.5, Newton2DOptions{Tol: 1e-12, MaxIter: 50, Bounds: bounds})
+	if err != nil {
+		t.Fatalf("Newton2DSolve failed: %v", err)
+	}
+	if math.Abs(res.X-2) > 1e-9 || math.Abs(res.Y-2) > 1e-9 {
+		t.Errorf("Expected (2, 2), got (%v, %v)", res.X, res.Y)
+	}
+	if visited > bounds.XMax {
+		t.Errorf("Iterate left the box: x=%v", visited)
+	}
+}
+
+func TestNewton2DSolve_MaxRelStep(t *testing.T) {
+	funcJS := func(x, y float64) (f1, f2, J11, J12, J21, J22 float64) {
+		f1 = x - 100
+		f2 = y - 1
+		J11 = 1
+		J22 = 1
+		return
+	}
+
+	// Each step at most doubles x: 1 -> 2 -> 4 -> ... -> 64 -> 100
+	res, err := Newton2DSolve(funcJS, 1, 1, Newton2DOptions{Tol: 1e-12, MaxIter: 50, MaxRelStep: 1})
+	if err != nil {
+		t.Fatalf("Newton2DSolve failed: %v", err)
+	}
+	if res.Iterations != 7 {
+		t.Errorf("Expected 7 limited steps, got %d", res.Iterations)
+	}
+}
+
+func TestNewton2DSolve_Diagnostics(t *testing.T) {
+	// Singular Jacobian: both equations depend on x + y only
+	singular := func(x, y float64) (f1, f2, J11, J12, J21, J22 float64) {
+		f1 = x + y - 1
+		f2 = 2*(x+y) - 3
+		J11, J12 = 1, 1
+		J21, J22 = 2, 2
+		return
+	}
+	res, err := Newton2DSolve(singular, 0, 0, Newton2DOptions{Tol: 1e-12, MaxIter: 50})
+	if err == nil || res.Reason != SingularJacobian {
+		t.Errorf("Expected a singular Jacobian, got reason %v, err %v", res.Reason, err)
+	}
+
+	// Iteration limit
+	slow := func(x, y float64) (f1, f2, J11, J12, J21, J22 float64) {
+		f1 = x*x*x - 8
+		f2 = y
+		J11 = 3 * x * x
+		J22 = 1
+		return
+	}
+	res, err = Newton2DSolve(slow, 100, 0, Newton2DOptions{Tol: 1e-12, MaxIter: 2})
+	if err == nil || res.Reason != MaxIterations || res.Iterations != 2 {
+		t.Errorf("Expected max iterations after 2 steps, got reason %v after %d, err %v", res.Reason, res.Iterations, err)
+	}
+	if res.F1 != res.X*res.X*res.X-8 {
+		t.Errorf("Residual F1=%v does not match final iterate x=%v", res.F1, res.X)
+	}
+	t.Logf("Stopped: %v", res.Reason)
+
+	// A negative iteration limit is rejected
+	res, err = Newton2DSolve(slow, 100, 0, Newton2DOptions{Tol: 1e-12, MaxIter: -1})
+	if err == nil || res.Reason != InvalidOptions || res.Iterations != 0 {
+		t.Errorf("Expected invalid options, got reason %v after %d, err %v", res.Reason, res.Iterations, err)
+	}
+}
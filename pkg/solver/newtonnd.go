@@ -0,0 +1,240 @@
+package solver
+
+import (
+	"fmt"
+	"math"
+)
+
+// ResidualFunc returns the residuals f(x).
+type ResidualFunc func(x []float64) []float64
+
+// ResidualJacobianFunc returns the residuals f(x) and the Jacobian
+// J[i][j] = ∂f_i/∂x_j.
+type ResidualJacobianFunc func(x []float64) (f []float64, J [][]float64)
+
+// NewtonNDOptions configures NewtonND. The fields mirror Newton2DOptions.
+type NewtonNDOptions struct {
+	Tol     float64 // convergence tolerance on every |f_i|
+	MaxIter int     // maximum number of Newton steps, at least 0
+
+	// StepTol, if positive, also accepts convergence when every relative
+	// step |Δx_j| <= StepTol·|x_j|.
+	StepTol float64
+
+	// MaxRelStep, if positive, limits each step to |Δx_j| <= MaxRelStep·|x_j|,
+	// scaling all components together.
+	MaxRelStep float64
+
+	// Lower and Upper, if set, bound x component-wise: a step that would
+	// leave the box is shortened to stop halfway to the boundary. Each must
+	// have one entry per unknown.
+	Lower, Upper []float64
+}
+
+// NewtonNDResult holds the final iterate and convergence diagnostics.
+type NewtonNDResult struct {
+	X          []float64  // final iterate
+	F          []float64  // residuals at X
+	Iterations int        // Newton steps taken
+	Reason     StopReason // why the iteration stopped
+}
+
+// NewtonND solves the square system f(x) = 0 by Newton's method with a dense
+// LU solve for each step and the same safeguards as Newton2DSolve: a
+// backtracking line search on the residual norm (each residual scaled by
+// Σ_j |J_ij|·max(|x_j|, 1)), optional bounds and a step limiter.
+// The returned result is filled in even when err is non-nil.
+func NewtonND(fj ResidualJacobianFunc, x0 []float64, opts NewtonNDOptions) (NewtonNDResult, error) {
+	const (
+		armijo        = 1e-4
+		maxBacktracks = 30
+	)
+
+	n := len(x0)
+	x := append([]float64(nil), x0...)
+	if opts.MaxIter < 0 {
+		return NewtonNDResult{X: x, Reason: InvalidOptions}, fmt.Errorf("negative MaxIter %d", opts.MaxIter)
+	}
+	if opts.Lower != nil && len(opts.Lower) != n {
+		return NewtonNDResult{X: x, Reason: InvalidOptions}, fmt.Errorf("NewtonND: %d lower bounds for %d unknowns", len(opts.Lower), n)
+	}
+	if opts.Upper != nil && len(opts.Upper) != n {
+		return NewtonNDResult{X: x, Reason: InvalidOptions}, fmt.Errorf("NewtonND: %d upper bounds for %d unknowns", len(opts.Upper), n)
+	}
+	if opts.Lower != nil || opts.Upper != nil {
+		for j := range x {
+			if opts.Lower != nil {
+				x[j] = math.Max(x[j], opts.Lower[j])
+			}
+			if opts.Upper != nil {
+				x[j] = math.Min(x[j], opts.Upper[j])
+			}
+		}
+	}
+
+	f, J := fj(x)
+	if len(f) != n {
+		return NewtonNDResult{X: x, F: f}, fmt.Errorf("NewtonND: %d residuals for %d unknowns", len(f), n)
+	}
+	res := NewtonNDResult{X: x, F: f}
+
+	for i := 0; ; i++ {
+		res.Iterations = i
+
+		if !allFinite(f) {
+			res.Reason = NonFinite
+			return res, fmt.Errorf("non-finite residuals at iter %d (x=%v)", i, x)
+		}
+
+		// Check convergence on residuals
+		if maxAbs(f) < opts.Tol {
+			res.Reason = Converged
+			return res, nil
+		}
+
+		if i == opts.MaxIter {
+			res.Reason = MaxIterations
+			return res, fmt.Errorf("max iterations (%d) reached without convergence (x=%v, f=%v)", opts.MaxIter, x, f)
+		}
+
+		// Newton step: J·dx = -f
+		minusF := make([]float64, n)
+		for k := range f {
+			minusF[k] = -f[k]
+		}
+		dx, err := SolveLinear(J, minusF)
+		if err != nil {
+			res.Reason = SingularJacobian
+			return res, fmt.Errorf("singular Jacobian at iter %d (x=%v)", i, x)
+		}
+
+		// Limit the step size, keeping its direction
+		scale := 1.0
+		if opts.MaxRelStep > 0 {
+			for j := range dx {
+				if limit := opts.MaxRelStep * math.Abs(x[j]); math.Abs(dx[j]) > limit && limit > 0 {
+					scale = math.Min(scale, limit/math.Abs(dx[j]))
+				}
+			}
+		}
+
+		// Stay inside the box, stopping halfway to a boundary
+		for j := range dx {
+			if opts.Lower != nil && x[j]+scale*dx[j] < opts.Lower[j] && dx[j] < 0 {
+				scale = math.Min(scale, 0.5*(opts.Lower[j]-x[j])/dx[j])
+			}
+			if opts.Upper != nil && x[j]+scale*dx[j] > opts.Upper[j] && dx[j] > 0 {
+				scale = math.Min(scale, 0.5*(opts.Upper[j]-x[j])/dx[j])
+			}
+		}
+		for j := range dx {
+			dx[j] *= scale
+		}
+
+		// A step at the level of rounding noise cannot improve the residuals
+		if opts.StepTol > 0 && smallStep(x, dx, opts.StepTol) {
+			for j := range x {
+				x[j] += dx[j]
+			}
+			res.X = x
+			res.Reason = ConvergedStep
+			return res, nil
+		}
+
+		// Backtracking line search on the scaled residual norm
+		s := make([]float64, n)
+		for k := range J {
+			for j := range J[k] {
+				s[k] += math.Abs(J[k][j]) * math.Max(math.Abs(x[j]), 1)
+			}
+			if s[k] == 0 || !isFinite(s[k]) {
+				s[k] = 1
+			}
+		}
+		norm := func(f []float64) float64 {
+			sum := 0.0
+			for k := range f {
+				sum += (f[k] / s[k]) * (f[k] / s[k])
+			}
+			return sum
+		}
+
+		norm0 := norm(f)
+		lambda := 1.0
+		accepted := false
+		xt := make([]float64, n)
+		for k := 0; k <= maxBacktracks; k++ {
+			for j := range x {
+				xt[j] = x[j] + lambda*dx[j]
+			}
+			ft, Jt := fj(xt)
+			if allFinite(ft) && norm(ft) <= (1-2*armijo*lambda)*norm0 {
+				x, f, J = append([]float64(nil), xt...), ft, Jt
+				accepted = true
+				break
+			}
+			lambda *= 0.5
+		}
+
+		res.X, res.F = x, f
+		if !accepted {
+			res.Iterations = i + 1
+			res.Reason = LineSearchFailed
+			return res, fmt.Errorf("line search failed at iter %d (x=%v, f=%v)", i, x, f)
+		}
+	}
+}
+
+// FiniteDifferenceJacobian wraps a residual function into one that also
+// returns a forward-difference Jacobian, for use with NewtonND or
+// LevenbergMarquardt when no analytic Jacobian is available.
+// The step for x_j is sqrt(ε)·max(|x_j|, 1).
+func FiniteDifferenceJacobian(f ResidualFunc) ResidualJacobianFunc {
+	return func(x []float64) ([]float64, [][]float64) {
+		f0 := f(x)
+		J := make([][]float64, len(f0))
+		for i := range J {
+			J[i] = make([]float64, len(x))
+		}
+
+		xh := append([]float64(nil), x...)
+		for j := range x {
+			h := math.Sqrt(MachineEpsilon) * math.Max(math.Abs(x[j]), 1)
+			xh[j] = x[j] + h
+			// Use the representable step to reduce rounding error
+			h = xh[j] - x[j]
+			fh := f(xh)
+			for i := range f0 {
+				J[i][j] = (fh[i] - f0[i]) / h
+			}
+			xh[j] = x[j]
+		}
+		return f0, J
+	}
+}
+
+func allFinite(v []float64) bool {
+	for _, x := range v {
+		if !isFinite(x) {
+			return false
+		}
+	}
+	return true
+}
+
+func maxAbs(v []float64) float64 {
+	m := 0.0
+	for _, x := range v {
+		m = math.Max(m, math.Abs(x))
+	}
+	return m
+}
+
+func smallStep(x, dx []float64, tol float64) bool {
+	for j := range x {
+		if math.Abs(dx[j]) > tol*math.Abs(x[j]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,140 @@
+package solver
+
+import (
+	"math"
+	"testing"
+)
+
+// rosenbrock is the Rosenbrock function written as a system:
+// f1 = 10(x2 - x1²), f2 = 1 - x1, with root (1, 1).
+func rosenbrock(x []float64) ([]float64, [][]float64) {
+	f := []float64{10 * (x[1] - x[0]*x[0]), 1 - x[0]}
+	J := [][]float64{
+		{-20 * x[0], 10},
+		{-1, 0},
+	}
+	return f, J
+}
+
+func TestNewtonND_Rosenbrock(t *testing.T) {
+	res, err := NewtonND(rosenbrock, []float64{-1.2, 1}, NewtonNDOptions{Tol: 1e-12, MaxIter: 50})
+	if err != nil {
+		t.Fatalf("NewtonND failed: %v (reason %v)", err, res.Reason)
+	}
+	if math.Abs(res.X[0]-1) > 1e-10 || math.Abs(res.X[1]-1) > 1e-10 {
+		t.Errorf("Expected (1, 1), got %v", res.X)
+	}
+	t.Logf("Converged in %d iterations: %v", res.Iterations, res.Reason)
+}
+
+func TestNewtonND_PowellBadlyScaled(t *testing.T) {
+	// f1 = 1e4·x1·x2 - 1, f2 = exp(-x1) + exp(-x2) - 1.0001
+	fj := func(x []float64) ([]float64, [][]float64) {
+		f := []float64{1e4*x[0]*x[1] - 1, math.Exp(-x[0]) + math.Exp(-x[1]) - 1.0001}
+		J := [][]float64{
+			{1e4 * x[1], 1e4 * x[0]},
+			{-math.Exp(-x[0]), -math.Exp(-x[1])},
+		}
+		return f, J
+	}
+
+	res, err := NewtonND(fj, []float64{0, 1}, NewtonNDOptions{Tol: 1e-12, MaxIter: 200})
+	if err != nil {
+		t.Fatalf("NewtonND failed: %v (reason %v)", err, res.Reason)
+	}
+	// Solution: (1.098159e-5, 9.106146)
+	if math.Abs(res.X[0]-1.098159e-5)/1.098159e-5 > 1e-6 || math.Abs(res.X[1]-9.106146)/9.106146 > 1e-6 {
+		t.Errorf("Unexpected solution %v", res.X)
+	}
+}
+
+func TestNewtonND_BroydenTridiagonal(t *testing.T) {
+	// f_i = (3 - 2x_i)·x_i - x_{i-1} - 2x_{i+1} + 1 with x_0 = x_{n+1} = 0,
+	// solved with a finite-difference Jacobian
+	const n = 10
+	f := func(x []float64) []float64 {
+		r := make([]float64, n)
+		for i := 0; i < n; i++ {
+			prev, next := 0.0, 0.0
+			if i > 0 {
+				prev = x[i-1]
+			}
+			if i < n-1 {
+				next = x[i+1]
+			}
+			r[i] = (3-2*x[i])*x[i] - prev - 2*next + 1
+		}
+		return r
+	}
+
+	x0 := make([]float64, n)
+	for i := range x0 {
+		x0[i] = -1
+	}
+
+	res, err := NewtonND(FiniteDifferenceJacobian(f), x0, NewtonNDOptions{Tol: 1e-10, MaxIter: 50})
+	if err != nil {
+		t.Fatalf("NewtonND failed: %v (reason %v)", err, res.Reason)
+	}
+	if maxAbs(f(res.X)) > 1e-10 {
+		t.Errorf("Residuals not converged: %v", f(res.X))
+	}
+	t.Logf("Converged in %d iterations", res.Iterations)
+}
+
+func TestNewtonND_Bounds(t *testing.T) {
+	// x² = 4 from x = 0.5 overshoots past the upper bound on the first step
+	fj := func(x []float64) ([]float64, [][]float64) {
+		return []float64{x[0]*x[0] - 4}, [][]float64{{2 * x[0]}}
+	}
+
+	maxVisited := 0.0
+	tracked := func(x []float64) ([]float64, [][]float64) {
+		maxVisited = math.Max(maxVisited, x[0])
+		return fj(x)
+	}
+
+	res, err := NewtonND(tracked, []float64{0.5}, NewtonNDOptions{Tol: 1e-12, MaxIter: 50, Lower: []float64{0}, Upper: []float64{3}})
+	if err != nil {
+		t.Fatalf("NewtonND failed: %v", err)
+	}
+	if math.Abs(res.X[0]-2) > 1e-12 || maxVisited > 3 {
+		t.Errorf("Expected x=2 inside [0, 3], got %v (visited %v)", res.X[0], maxVisited)
+	}
+}
+
+func TestNewtonND_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts NewtonNDOptions
+	}{
+		{"negative MaxIter", NewtonNDOptions{Tol: 1e-12, MaxIter: -1}},
+		{"short Lower", NewtonNDOptions{Tol: 1e-12, MaxIter: 50, Lower: []float64{0}}},
+		{"long Upper", NewtonNDOptions{Tol: 1e-12, MaxIter: 50, Upper: []float64{3, 3, 3}}},
+	}
+	for _, tt := range tests {
+		res, err := NewtonND(rosenbrock, []float64{-1.2, 1}, tt.opts)
+		if err == nil || res.Reason != InvalidOptions || res.Iterations != 0 {
+			t.Errorf("%s: expected invalid options, got reason %v after %d, err %v", tt.name, res.Reason, res.Iterations, err)
+		}
+	}
+}
+
+func TestFiniteDifferenceJacobian(t *testing.T) {
+	fd := FiniteDifferenceJacobian(func(x []float64) []float64 {
+		f, _ := rosenbrock(x)
+		return f
+	})
+
+	x := []float64{0.3, -0.7}
+	_, J := rosenbrock(x)
+	_, Jfd := fd(x)
+
+	for i := range J {
+		for j := range J[i] {
+			if math.Abs(Jfd[i][j]-J[i][j]) > 1e-6*math.Max(math.Abs(J[i][j]), 1) {
+				t.Errorf("J[%d][%d] = %v, expected %v", i, j, Jfd[i][j], J[i][j])
+			}
+		}
+	}
+}
@@ -2,40 +2,86 @@ package transport
 
 import (
 	"GOcoolprop/pkg/fluid"
+	"errors"
 	"fmt"
 	"math"
 )
 
-// Conductivity calculates the thermal conductivity in W/(m*K).
+// Conductivity calculates the thermal conductivity in W/(m*K) with the
+// first model in f.Transport.Conductivity that can be evaluated, falling
+// back to the next on an error, like Viscosity. Fluids without conductivity
+// data get the Chung estimate, see ConductivityEstimated.
 func Conductivity(f *fluid.FluidData, T, Rho float64) (float64, error) {
-	if f.Transport.Conductivity.Hardcoded != "" {
-		return 0, fmt.Errorf("hardcoded conductivity for %s not implemented yet", f.Info.Name)
+	models := f.Transport.Conductivity
+	if len(models) == 0 {
+		return ConductivityChung(f, T, Rho), nil
+	}
+
+	var errs []error
+	for i := range models {
+		lambda, err := ConductivityModel(f, i, T, Rho)
+		if err == nil {
+			return lambda, nil
+		}
+		errs = append(errs, err)
+	}
+	return 0, errors.Join(errs...)
+}
+
+// ConductivityModel calculates the thermal conductivity in W/(m*K) with
+// model i of f.Transport.Conductivity, without falling back to the others.
+func ConductivityModel(f *fluid.FluidData, i int, T, Rho float64) (float64, error) {
+	if i < 0 || i >= len(f.Transport.Conductivity) {
+		return 0, fmt.Errorf("%s has no conductivity model %d", f.Info.Name, i)
+	}
+	m := &f.Transport.Conductivity[i]
+
+	if m.Hardcoded != "" {
+		return conductivityHardcoded(f, m.Hardcoded, T, Rho)
+	}
+	switch m.Type {
+	case "":
+	case "ECS":
+		return conductivityECS(f, m, T, Rho)
+	case "Chung":
+		return ConductivityChung(f, T, Rho), nil
+	default:
+		return 0, fmt.Errorf("%s conductivity model for %s not implemented yet", m.Type, f.Info.Name)
 	}
 
 	// 1. Dilute Gas Contribution
-	lambda0, err := ConductivityDilute(f, T)
+	lambda0, err := ConductivityDilute(f, m, T)
 	if err != nil {
 		return 0, err
 	}
 
 	// 2. Residual Contribution
-	lambdaRes, err := ConductivityResidual(f, T, Rho)
+	lambdaRes, err := ConductivityResidual(f, m, T, Rho)
 	if err != nil {
 		return 0, err
 	}
 
-	// 3. Critical Enhancement (Optional/TODO)
-	// lambdaCrit := ...
+	// 3. Critical Enhancement
+	lambdaCrit, err := ConductivityCritical(f, m.Critical, T, Rho)
+	if err != nil {
+		return 0, err
+	}
 
-	return lambda0 + lambdaRes, nil
+	return lambda0 + lambdaRes + lambdaCrit, nil
 }
 
-func ConductivityDilute(f *fluid.FluidData, T float64) (float64, error) {
-	d := f.Transport.Conductivity.Dilute
+// ConductivityDilute calculates the dilute-gas conductivity of model m in
+// W/(m*K).
+func ConductivityDilute(f *fluid.FluidData, m *fluid.ConductivityData, T float64) (float64, error) {
+	d := m.Dilute
 	if d == nil {
 		return 0, nil
 	}
 
+	if d.Hardcoded != "" {
+		return conductivityDiluteHardcoded(f, d.Hardcoded, T)
+	}
+
 	if d.Type == "polynomial_and_exponential" || d.Type == "rational_polynomial" {
 		// lambda0 = sum(A_i * T^i) / sum(B_i * T^i)
 
@@ -49,8 +95,52 @@ func ConductivityDilute(f *fluid.FluidData, T float64) (float64, error) {
 			den = 0.0
 			for i, b := range d.B {
 				den += b * math.Pow(T, float64(i))
-func ConductivityResidual(f *fluid.FluidData, T, Rho float64) (float64, error) {
-	r := f.Transport.Conductivity.Residual
+			}
+		}
+
+		return num / den, nil
+	}
+
+	if d.Type == "ratio_of_polynomials" {
+		// lambda0 = sum(A_i * Tr^n_i) / sum(B_i * Tr^m_i), Tr = T / T_reducing
+		Tr := T / d.TReducing
+
+		num := 0.0
+		for i, a := range d.A {
+			num += a * math.Pow(Tr, d.N[i])
+		}
+		den := 0.0
+		for i, b := range d.B {
+			den += b * math.Pow(Tr, d.M[i])
+		}
+
+		return num / den, nil
+	}
+
+	if d.Type == "eta0_and_poly" {
+		// lambda0 = A_0 * eta0[uPa*s] + sum(A_i * tau^t_i), i >= 1
+		eta0, err := dilutePartViscosity(f, T)
+		if err != nil {
+			return 0, err
+		}
+
+		tau := f.States.Critical.T / T
+
+		sum := d.A[0] * eta0 * 1e6
+		for i := 1; i < len(d.A); i++ {
+			sum += d.A[i] * math.Pow(tau, d.T[i])
+		}
+
+		return sum, nil
+	}
+
+	return 0, fmt.Errorf("unknown dilute conductivity type: %s", d.Type)
+}
+
+// ConductivityResidual calculates the residual conductivity of model m in
+// W/(m*K).
+func ConductivityResidual(f *fluid.FluidData, m *fluid.ConductivityData, T, Rho float64) (float64, error) {
+	r := m.Residual
 	if r == nil {
 		return 0, nil
 	}
@@ -79,5 +169,30 @@ func ConductivityResidual(f *fluid.FluidData, T, Rho float64) (float64, error) {
 		return sum, nil
 	}
 
+	if r.Type == "polynomial" {
+		// lambda_res = sum(B_i * tau^t_i * delta^d_i), with delta from the
+		// mass density
+		tau := r.TReducing / T
+		delta := Rho * f.EOS[0].MolarMass / r.RhoMassReducing
+
+		sum := 0.0
+		for i := range r.B {
+			sum += r.B[i] * math.Pow(tau, r.T[i]) * math.Pow(delta, r.D[i])
+		}
+
+		return sum, nil
+	}
+
 	return 0, fmt.Errorf("unknown residual conductivity type: %s", r.Type)
 }
+
+// dilutePartViscosity returns the dilute-gas viscosity in Pa*s of the first
+// viscosity model of f that has a dilute term.
+func dilutePartViscosity(f *fluid.FluidData, T float64) (float64, error) {
+	for i := range f.Transport.Viscosity {
+		if m := &f.Transport.Viscosity[i]; m.Dilute != nil {
+			return ViscosityDilute(f, m, T)
+		}
+	}
+	return 0, fmt.Errorf("no dilute viscosity for %s", f.Info.Name)
+}
@@ -0,0 +1,333 @@
+package transport
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"fmt"
+	"math"
+)
+
+// conductivityHardcoded calculates the thermal conductivity in W/(m*K) with
+// the reference correlation of the given name, for fluids whose
+// conductivity does not follow the dilute, residual and critical sum.
+func conductivityHardcoded(f *fluid.FluidData, name string, T, Rho float64) (float64, error) {
+	switch name {
+	case "Water":
+		return conductivityWater(f, T, Rho)
+	case "HeavyWater":
+		return conductivityHeavyWater(f, T, Rho), nil
+	case "Helium":
+		return conductivityHelium(f, T, Rho)
+	case "Methane":
+		return conductivityMethane(f, T, Rho), nil
+	case "R23":
+		return conductivityR23(T, Rho), nil
+	}
+	return 0, fmt.Errorf("hardcoded conductivity %s for %s not implemented", name, f.Info.Name)
+}
+
+// conductivityDiluteHardcoded calculates the dilute-gas conductivity in
+// W/(m*K) with the reference correlation of the given name.
+func conductivityDiluteHardcoded(f *fluid.FluidData, name string, T float64) (float64, error) {
+	switch name {
+	case "CarbonDioxideHuberJPCRD2016":
+		// Huber et al., JPCRD 45 (2016) 013102, Eq. (3), in mW/(m*K)
+		tau := f.States.Critical.T / T
+		l := []float64{0.0151874307, 0.0280674040, 0.0228564190, -0.00741624210}
+		return 1 / math.Sqrt(tau) / (l[0] + l[1]*tau + l[2]*tau*tau + l[3]*tau*tau*tau) / 1000, nil
+	case "Ethane":
+		// Friend et al., JPCRD 20 (1991) 275: from the dilute-gas viscosity
+		// and the ideal gas heat capacity
+		eta0, err := dilutePartViscosity(f, T)
+		if err != nil {
+			return 0, err
+		}
+		fint := 1.7104147 - 0.6936482/(T/245.0)
+		return 0.276505e-3 * eta0 * 1e6 * (3.75 - fint*(idealGasTau2(f, T)+1.5)), nil
+	}
+	return 0, fmt.Errorf("hardcoded dilute conductivity %s for %s not implemented", name, f.Info.Name)
+}
+
+// conductivityCriticalHardcoded calculates the critical enhancement of the
+// thermal conductivity in W/(m*K) with the correlation of the given name.
+func conductivityCriticalHardcoded(f *fluid.FluidData, name string, T, Rho float64) (float64, error) {
+	switch name {
+	case "R123":
+		// Laesecke et al., Int. J. Thermophys. 17 (1996) 617
+		state := core.NewState(f)
+		state.Update(T, Rho)
+		return 0.486742e-2 * math.Exp(-100*math.Pow(state.Tau-1, 4)-7.08535*math.Pow(state.Delta-1, 2)), nil
+	case "Ammonia":
+		return conductivityCriticalAmmonia(T, Rho*f.EOS[0].MolarMass), nil
+	}
+	return 0, fmt.Errorf("hardcoded critical conductivity %s for %s not implemented", name, f.Info.Name)
+}
+
+// idealGasTau2 returns tau² * d²alpha0/dtau² of f at T, i.e. -cp0/R + 1.
+func idealGasTau2(f *fluid.FluidData, T float64) float64 {
+	state := core.NewState(f)
+	state.Update(T, 1)
+	_, _, _, _, a0tt, _ := state.HE.UpdateIdealGas(state.Tau, state.Delta)
+	return state.Tau * state.Tau * a0tt
+}
+
+// ---- Water (IAPWS 2011) ----
+
+// conductivityWater is the IAPWS 2011 formulation for the thermal
+// conductivity of ordinary water, Huber et al., JPCRD 41 (2012) 033102,
+// including the critical enhancement.
+func conductivityWater(f *fluid.FluidData, T, Rho float64) (float64, error) {
+	const (
+		Tstar, rhostar = 647.096, 322.0
+		lambdastar     = 1e-3
+		mustar         = 1e-6
+		nu, gamma      = 0.630, 1.239
+		GAMMA          = 177.8514
+		xi0, Lambda0   = 0.13, 0.06
+		qdbar          = 1 / 0.4
+	)
+	L := [5][6]float64{
+		{1.60397357, -0.646013523, 0.111443906, 0.102997357, -0.0504123634, 0.00609859258},
+		{2.33771842, -2.78843778, 1.53616167, -0.463045512, 0.0832827019, -0.00719201245},
+		{2.19650529, -4.54580785, 3.55777244, -1.40944978, 0.275418278, -0.0205938816},
+		{-1.21051378, 1.60812989, -0.621178141, 0.0716373224, 0, 0},
+		{-2.7203370, 4.57586331, -3.18369245, 1.1168348, -0.19268305, 0.012913842},
+	}
+	Tbar := T / Tstar
+	rhobar := Rho * f.EOS[0].MolarMass / rhostar
+
+	// Dilute gas and finite density contributions
+	lambda0 := math.Sqrt(Tbar) / (2.443221e-3 + 1.323095e-2/Tbar + 6.770357e-3/math.Pow(Tbar, 2) -
+		3.454586e-3/math.Pow(Tbar, 3) + 4.096266e-4/math.Pow(Tbar, 4))
+	sum := 0.0
+	for i := range L {
+		for j := range L[i] {
+			sum += L[i][j] * math.Pow(1/Tbar-1, float64(i)) * math.Pow(rhobar-1, float64(j))
+		}
+	}
+	lambda1 := math.Exp(rhobar * sum)
+
+	// Critical enhancement
+	state := core.NewState(f)
+	state.Update(T, Rho)
+	R := f.EOS[0].GasConstant
+	cpbar := state.Cp() / R
+	kappa := state.Cp() / state.Cv()
+	eta := viscosityWater(f, T, Rho)
+	mubar := eta / mustar
+
+	xi := 0.0
+	if chi := waterDeltaChi(f, T, Rho); chi > 0 {
+		xi = xi0 * math.Pow(chi/Lambda0, nu/gamma)
+	}
+	y := qdbar * xi
+	Z := 0.0
+	if y >= 1.2e-7 {
+		Z = 2 / (math.Pi * y) * (((1-1/kappa)*math.Atan(y) + y/kappa) - (1 - math.Exp(-1/(1/y+y*y/3/rhobar/rhobar))))
+	}
+	lambda2 := GAMMA * rhobar * cpbar * Tbar / mubar * Z
+
+	return (lambda0*lambda1 + lambda2) * lambdastar, nil
+}
+
+// ---- Other fluids ----
+
+// conductivityHeavyWater is the IAPWS 1994 formulation for heavy water.
+func conductivityHeavyWater(f *fluid.FluidData, T, Rho float64) float64 {
+	Tbar, rhobar := T/643.847, Rho*f.EOS[0].MolarMass/358
+	A := []float64{1.00000, 37.3223, 22.5485, 13.0465, 0, -2.60735}
+	lambda0 := 0.0
+	for i, a := range A {
+		lambda0 += a * math.Pow(Tbar, float64(i))
+	}
+	Be, B := -2.506, []float64{-167.310, 483.656, -191.039, 73.0358, -7.57467}
+	DeltaLambda := B[0] * (1 - math.Exp(Be*rhobar))
+	for i := 1; i < len(B); i++ {
+		DeltaLambda += B[i] * math.Pow(rhobar, float64(i))
+	}
+
+	f1 := math.Exp(0.144847*Tbar - 5.64493*Tbar*Tbar)
+	f2 := math.Exp(-2.80000*math.Pow(rhobar-1, 2)) - 0.080738543*math.Exp(-17.9430*math.Pow(rhobar-0.125698, 2))
+	tau := Tbar / (math.Abs(Tbar-1.1) + 1.1)
+	f3 := 1 + math.Exp(60*(tau-1)+20)
+	f4 := 1 + math.Exp(100*(tau-1)+15)
+	DeltaLambdaC := 35429.6 * f1 * f2 * (1 + f2*f2*(5000.0e6*math.Pow(f1, 4)/f3+3.5*f2/f4))
+	DeltaLambdaL := -741.112 * math.Pow(f1, 1.2) * (1 - math.Exp(-math.Pow(rhobar/2.5, 10)))
+
+	return (lambda0 + DeltaLambda + DeltaLambdaC + DeltaLambdaL) * 0.742128e-3
+}
+
+// conductivityHelium is the correlation of Hands and Arp (1981) as given by
+// Arp, McCarty and Friend, NIST TN 1334 (1998), for helium-4.
+func conductivityHelium(f *fluid.FluidData, T, Rho float64) (float64, error) {
+	const rhoc = 68.0
+	rho := Rho * f.EOS[0].MolarMass // kg/m³
+
+	sum := 3.739232544/T - 2.620316969e1/(T*T) + 5.982252246e1/(T*T*T) - 4.926397634e1/(T*T*T*T)
+	lambda0 := 2.7870034e-3 * math.Pow(T, 7.034007057e-1) * math.Exp(sum)
+
+	c := []float64{1.862970530e-4, -7.275964435e-7, -1.427549651e-4, 3.290833592e-5, -5.213335363e-8, 4.492659933e-8,
+		-5.924416513e-9, 7.087321137e-6, -6.013335678e-6, 8.067145814e-7, 3.995125013e-7}
+	T1, T2 := math.Cbrt(T), math.Pow(T, 2.0/3.0)
+	lambdaE := (c[0]+c[1]*T+c[2]*T1+c[3]*T2)*rho +
+		(c[4]+c[5]*T1+c[6]*T2)*rho*rho*rho +
+		(c[7]+c[8]*T1+c[9]*T2+c[10]/T)*rho*rho*math.Log(rho/rhoc)
+
+	// Critical enhancement
+	lambdaC := 0.0
+	if 3.5 < T && T < 12 {
+		const (
+			x0, E1, E2         = 0.392, 2.8461, 0.27156
+			beta, gamma, delta = 0.3554, 1.1743, 4.304
+			rhocCrit, Tc, pc   = 69.158, 5.18992, 2.2746e5
+		)
+		DeltaT, DeltaRho := math.Abs(1-T/Tc), math.Abs(1-rho/rhocCrit)
+		eta := viscosityHelium(f, T, Rho)
+
+		state := core.NewState(f)
+		state.Update(T, Rho)
+		KT := 1 / (Rho * state.DPdRho())
+		dpdT := state.DPdT()
+
+		W := math.Pow(DeltaT/0.2, 2) + math.Pow(DeltaRho/0.25, 2)
+		KTbar := KT
+		if W <= 1 {
+			// Compressibility of the scaled equation near the critical point
+			x := math.Pow(DeltaT/DeltaRho, 1/beta)
+			u := (x + x0) / x0
+			v := E2*math.Pow(u, 2/beta) + 1
+			h := E1 * u * math.Pow(v, (gamma-1)/(2*beta))
+			dhdx := E1 * (E2*math.Pow(u, 2/beta)*(gamma-1)*math.Pow(v, 0.5*(gamma-1)/beta) +
+				beta*beta*math.Pow(v, 0.5*(2*beta+gamma-1)/beta)) / (beta * beta * x0 * v)
+			RHS := math.Pow(DeltaRho, delta-1) * (delta*h - x/beta*dhdx)
+			KTprime := 1 / (RHS * math.Pow(rho/rhocCrit, 2) * pc)
+			KTbar = W*KT + (1-W)*KTprime
+		}
+
+		// Coefficients of the REFPROP implementation
+		lambdaC = 3.4685233e-17 * 3.726229668 * math.Sqrt(KTbar) * T * T / rho / eta * dpdT * dpdT *
+			math.Exp(-18.66*DeltaT*DeltaT-4.25*math.Pow(DeltaRho, 4))
+	}
+	return lambda0 + lambdaE + lambdaC, nil
+}
+
+// conductivityMethane is the correlation of Friend, Ely and Ingham, JPCRD 18
+// (1989) 583, for methane.
+func conductivityMethane(f *fluid.FluidData, T, Rho float64) float64 {
+	delta, tau := Rho/10139.0, 190.55/T
+
+	// Dilute and residual viscosity in uPa*s
+	C := []float64{-3.0328138281, 16.918880086, -37.189364917, 41.288861858, -24.615921140,
+		8.9488430959, -1.8739245042, 0.20966101390, -9.6570437074e-3}
+	t := T / 174.0
+	omega := 0.0
+	for i, c := range C {
+		omega += c * math.Pow(t, float64(i)/3.0-1)
+	}
+	etaDilute := 10.50 * math.Sqrt(t) * omega
+	re := []float64{0, 1, 1, 2, 2, 2, 3, 3, 4, 4, 1, 1}
+	se := []float64{0, 0, 1, 0, 1, 1.5, 0, 2, 0, 1, 0, 1}
+	ge := []float64{0, 0.41250137, -0.14390912, 0.10366993, 0.40287464, -0.24903524,
+		-0.12953131, 0.06575776, 0.02566628, -0.03716526, -0.38798341, 0.03533815}
+	sum1, sum2 := 0.0, 0.0
+	for i := 1; i <= 9; i++ {
+		sum1 += ge[i] * math.Pow(delta, re[i]) * math.Pow(tau, se[i])
+	}
+	for i := 10; i <= 11; i++ {
+		sum2 += ge[i] * math.Pow(delta, re[i]) * math.Pow(tau, se[i])
+	}
+	eta := etaDilute + 12.149*sum1/(1+sum2)
+
+	state := core.NewState(f)
+	state.Update(T, Rho)
+	_, ard, _, ardd, _, ardt := state.Residual()
+
+	// Dilute, in mW/(m*K)
+	fint := 1.458850 - 0.4377162/t
+	lambdaDilute := 0.51828 * etaDilute * (3.75 - fint*(idealGasTau2(f, T)+1.5))
+
+	// Residual, with the saturated vapour density below Tc
+	rl := []float64{0, 1, 3, 4, 4, 5, 5, 2}
+	sl := []float64{0, 0, 0, 0, 1, 0, 1, 0}
+	jl := []float64{0, 2.4149207, 0.55166331, -0.52837734, 0.073809553, 0.24465507, -0.047613626, 1.5554612}
+	sum := 0.0
+	for i := 1; i <= 6; i++ {
+		sum += jl[i] * math.Pow(delta, rl[i]) * math.Pow(tau, sl[i])
+	}
+	deltaSigma := 1.0
+	if Tc, rhoc := f.States.Critical.T, f.States.Critical.RhoMolar; T < Tc && Rho < rhoc {
+		deltaSigma = f.Ancillaries.RhoV.Evaluate(T) / rhoc
+	}
+	lambdaResidual := 6.29638 * (sum + jl[7]*delta*delta/deltaSigma)
+
+	// Critical enhancement
+	Tstar, rhostar := 1-1/tau, 1-delta
+	F := math.Exp(-2.646*math.Sqrt(math.Abs(Tstar)) - 2.678*rhostar*rhostar + 0.637*rhostar)
+	chi := 0.28631 * delta * tau / (1 + 2*delta*ard + delta*delta*ardd)
+	if math.Abs(Tstar) < 0.03 {
+		if math.Abs(rhostar) < 1e-16 {
+			chi = 0.0801 * math.Pow(math.Abs(Tstar), -1.190)
+		} else if math.Abs(rhostar) < 0.03 {
+			const beta, W, S, E, a, b, R, Q = 0.355, -1.401, -6.098, 0.287, 3.352, 0.732, 0.535, 0.1133
+			rb := math.Pow(math.Abs(rhostar), -1/beta)
+			OMEGA := W * Tstar * rb
+			theta := 1.0
+			if Tstar < -rb/S {
+				theta = 1 + E*math.Pow(1+S*Tstar*rb, 2*beta)
+			}
+			chi = Q * math.Pow(math.Abs(rhostar), -a) * math.Pow(theta, b) / (theta + OMEGA*(theta+R))
+		}
+	}
+	lambdaCritical := 91.855 / (eta * tau * tau) * math.Pow(1+delta*ard-delta*tau*ardt, 2) *
+		math.Pow(chi, 0.4681) * F
+
+	return (lambdaDilute + lambdaResidual + lambdaCritical) * 0.001
+}
+
+// conductivityR23 is the correlation of Shan, Penoncello and Jacobsen,
+// ASHRAE Trans. 106 (2000).
+func conductivityR23(T, Rho float64) float64 {
+	const (
+		B1, B2         = -2.5370, 0.05366 // mW/(m*K), mW/(m*K²)
+		C1, C2         = 0.94215, 0.14914
+		DeltaGstar     = 2508.58 // J/mol
+		rhoL, rhocbar  = 68.345, 7.5114
+		DeltaLambdaMax = 25.0 // mW/(m*K)
+		Ru             = 8.31451
+		Tc             = 299.2793
+	)
+	lambdaDG := B1 + B2*T
+	rhobar := Rho / 1000 // mol/L
+	lambdaL := C2 * rhoL * rhoL / (rhoL - rhobar) * math.Sqrt(T) * math.Exp(rhobar/(rhoL-rhobar)*DeltaGstar/(Ru*T))
+	DeltaLambdaC := DeltaLambdaMax / (math.Cosh(rhobar-rhocbar) * math.Cosh(T-Tc))
+
+	return (math.Pow((rhoL-rhobar)/rhoL, C1)*lambdaDG + math.Pow(rhobar/rhoL, C1)*lambdaL + DeltaLambdaC) / 1e3
+}
+
+// conductivityCriticalAmmonia is the critical enhancement of Tufeu et al.,
+// Ber. Bunsenges. Phys. Chem. 88 (1984) 422, for ammonia; rho in kg/m³.
+func conductivityCriticalAmmonia(T, rho float64) float64 {
+	const (
+		Tc, rhoc         = 405.4, 235.0
+		LAMBDA, nu       = 1.2, 0.63
+		gamma, DELTA     = 1.24, 0.50
+		zeta0Plus, aZeta = 1.34e-10, 1.0
+		GAMMA0Plus       = 0.423e-8
+		kB               = 1.3806504e-23
+	)
+	t := math.Abs((T - Tc) / Tc)
+	aChi := aZeta / 0.7
+	etaB := (2.60 + 1.6*t) * 1e-5
+	dPdT := (2.18 - 0.12/math.Exp(17.8*t)) * 1e5
+	XT := 0.61*rhoc + 16.5*math.Log(t)
+
+	// Along the critical isochore (Eq. 9)
+	DeltaLambdaI := LAMBDA * kB * T * T / (6 * math.Pi * etaB * (zeta0Plus * math.Pow(t, -nu) * (1 + aZeta*math.Pow(t, DELTA)))) *
+		dPdT * dPdT * GAMMA0Plus * math.Pow(t, -gamma) * (1 + aChi*math.Pow(t, DELTA))
+	DeltaLambdaID := DeltaLambdaI * math.Exp(-36*t*t)
+
+	if rho < 0.6*rhoc {
+		return DeltaLambdaID * XT * XT / (XT*XT + math.Pow(0.6*rhoc-0.96*rhoc, 2)) * rho * rho / math.Pow(0.6*rhoc, 2)
+	}
+	return DeltaLambdaID * XT * XT / (XT*XT + math.Pow(rho-0.96*rhoc, 2))
+}
@@ -0,0 +1,115 @@
+package transport
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/solver"
+	"fmt"
+	"math"
+)
+
+// Boltzmann constant in J/K and Avogadro constant in 1/mol
+const (
+	boltzmann = 1.380649e-23
+	avogadro  = 6.02214076e23
+)
+
+// ConductivityCritical calculates the critical enhancement of the thermal
+// conductivity in W/(m*K) for the critical term c of a conductivity model
+// (nil for none).
+func ConductivityCritical(f *fluid.FluidData, c *fluid.ConductivityCrit, T, Rho float64) (float64, error) {
+	if c == nil {
+		return 0, nil
+	}
+	if c.Hardcoded != "" {
+		if c.Hardcoded == "None" {
+			return 0, nil
+		}
+		return conductivityCriticalHardcoded(f, c.Hardcoded, T, Rho)
+	}
+
+	if c.Type == "simplified_Olchowy_Sengers" {
+		return conductivityOlchowySengers(f, c, T, Rho)
+	}
+
+	return 0, fmt.Errorf("unknown critical conductivity type: %s", c.Type)
+}
+
+// conductivityOlchowySengers is the simplified crossover model of Olchowy
+// and Sengers (1989) as given by Lemmon and Jacobsen, Int. J. Thermophys. 25
+// (2004) 21:
+//
+//	lambda_c = rho*cp*R0*k*T / (6*pi*eta*zeta) * (Omega - Omega0)
+//
+// with the correlation length zeta from the difference of the reduced
+// susceptibility at T and at the reference temperature T_ref.
+func conductivityOlchowySengers(f *fluid.FluidData, c *fluid.ConductivityCrit, T, Rho float64) (float64, error) {
+	// Universal constants and default parameters
+	R0, gamma, nu := 1.03, 1.239, 0.63
+	GAMMA, zeta0, qD := 0.0496, 1.94e-10, 2e9
+	if c.R0 != 0 {
+		R0 = c.R0
+	}
+	if c.Gamma != 0 {
+		gamma = c.Gamma
+	}
+	if c.Nu != 0 {
+		nu = c.Nu
+	}
+	if c.GAMMA != 0 {
+		GAMMA = c.GAMMA
+	}
+	if c.Zeta0 != 0 {
+		zeta0 = c.Zeta0
+	}
+	if c.QD != 0 {
+		qD = c.QD
+	}
+
+	state := core.NewState(f)
+	state.Update(T, Rho)
+	R := f.EOS[0].GasConstant
+	delta := state.Delta
+
+	// Reducing state of the EOS
+	Tc := T * state.Tau
+	rhoc := Rho / delta
+	Pc := f.EOS[0].States.Critical.P
+	if Pc == 0 {
+		Pc = f.States.Critical.P
+	}
+	Tref := c.TRef
+	if Tref == 0 {
+		Tref = 1.5 * Tc
+	}
+
+	// Reduced susceptibility X = Pc*rho/rhoc^2 * (drho/dp)_T at T and at
+	// Tref
+	_, ar_d, _, ar_dd, _, _ := state.Residual()
+	dpdrho := R * T * (1 + 2*delta*ar_d + delta*delta*ar_dd)
+	X := Pc / (rhoc * rhoc) * Rho / dpdrho
+
+	_, arRef_d, _, arRef_dd, _, _ := state.HE.UpdateResidual(Tc/Tref, delta)
+	dpdrhoRef := R * Tref * (1 + 2*delta*arRef_d + delta*delta*arRef_dd)
+	Xref := Pc / (rhoc * rhoc) * Rho / dpdrhoRef * Tref / T
+
+	// No enhancement far from the critical point, or for a difference at
+	// the level of roundoff
+	num := X - Xref
+	if !(num > 10*solver.MachineEpsilon) {
+		return 0, nil
+	}
+	zeta := zeta0 * math.Pow(num/GAMMA, nu/gamma)
+
+	eta, err := Viscosity(f, T, Rho)
+	if err != nil {
+		return 0, fmt.Errorf("critical conductivity of %s: %w", f.Info.Name, err)
+	}
+	cp := state.Cp()
+	cv := state.Cv()
+
+	Omega := 2 / math.Pi * ((cp-cv)/cp*math.Atan(zeta*qD) + cv/cp*zeta*qD)
+	Omega0 := 2 / math.Pi * (1 - math.Exp(-1/(1/(qD*zeta)+(zeta*qD)*(zeta*qD)/(3*delta*delta))))
+
+	return Rho * cp * R0 * boltzmann * T / (6 * math.Pi * eta * zeta) * (Omega - Omega0), nil
+}
@@ -0,0 +1,269 @@
+package transport
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/solver"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// Extended corresponding states (ECS): the residual transport property of a
+// fluid is that of a reference fluid at the conformal state, the state with
+// the same residual Helmholtz energy and compressibility factor, scaled by
+// the ratios f = T/T0 and h = rho0/rho. The reference density is corrected
+// by the polynomial psi(rho) of the model. See Huber, Laesecke and Perkins,
+// Ind. Eng. Chem. Res. 42 (2003) 3163.
+
+// viscosityECS calculates the viscosity in Pa*s with an ECS model.
+func viscosityECS(f *fluid.FluidData, m *fluid.ViscosityData, T, Rho float64) (float64, error) {
+	if m.Psi == nil || m.SigmaEta == 0 || m.EpsilonOverK == 0 {
+		return 0, fmt.Errorf("incomplete ECS viscosity model for %s", f.Info.Name)
+	}
+	ref, err := referenceFluid(f, m.ReferenceFluid)
+	if err != nil {
+		return 0, err
+	}
+
+	// Dilute gas of the fluid itself
+	M := f.EOS[0].MolarMass
+	etaDilute := viscosityKineticTheory(M, m.SigmaEta, m.EpsilonOverK, T)
+
+	T0, Rho0, err := conformalState(f, ref, T, Rho)
+	if err != nil {
+		return 0, err
+	}
+	psi := ecsPolynomial(m.Psi, Rho/m.Psi.RhoMolarReducing)
+	etaRef, err := viscosityBackground(ref, T0, Rho0*psi)
+	if err != nil {
+		return 0, err
+	}
+
+	// F_eta = sqrt(f) * h^(-2/3) * sqrt(M/M0)
+	fT, h := T/T0, Rho0/Rho
+	F := math.Sqrt(fT) * math.Pow(h, -2.0/3.0) * math.Sqrt(M/ref.EOS[0].MolarMass)
+
+	return etaDilute + etaRef*F, nil
+}
+
+// conductivityECS calculates the thermal conductivity in W/(m*K) with an
+// ECS model. The dilute gas part, including the internal degrees of freedom
+// through f_int, and the critical enhancement are those of the fluid
+// itself.
+func conductivityECS(f *fluid.FluidData, m *fluid.ConductivityData, T, Rho float64) (float64, error) {
+	if m.Psi == nil || m.FInt == nil {
+		return 0, fmt.Errorf("incomplete ECS conductivity model for %s", f.Info.Name)
+	}
+	ref, err := referenceFluid(f, m.ReferenceFluid)
+	if err != nil {
+		return 0, err
+	}
+
+	// The Lennard-Jones parameters come from the viscosity models
+	sigma, epsilonOverK := lennardJones(f, nil)
+	for i := range f.Transport.Viscosity {
+		if v := &f.Transport.Viscosity[i]; v.SigmaEta > 0 && v.EpsilonOverK > 0 {
+			sigma, epsilonOverK = lennardJones(f, v)
+			break
+		}
+	}
+
+	M := f.EOS[0].MolarMass
+	Ru := f.EOS[0].GasConstant
+	R := Ru / M // J/(kg*K)
+
+	// Dilute gas viscosity in uPa*s
+	etaDilute := viscosityKineticTheory(M, sigma, epsilonOverK, T) * 1e6
+
+	state := core.NewState(f)
+	state.Update(T, Rho)
+	cp0 := state.IdealGasCp() / M // J/(kg*K)
+
+	// Internal and translational dilute gas contributions
+	fint := ecsPolynomial(m.FInt, T/m.FInt.TReducing)
+	lambdaInt := fint * etaDilute * (cp0 - 2.5*R) / 1e3
+	lambdaDilute := 15.0e-3 / 4.0 * R / 1e3 * etaDilute
+
+	T0, Rho0, err := conformalState(f, ref, T, Rho)
+	if err != nil {
+		return 0, err
+	}
+	psi := ecsPolynomial(m.Psi, Rho/m.Psi.RhoMolarReducing)
+	lambdaRef, err := conductivityBackground(ref, T0, Rho0*psi)
+	if err != nil {
+		return 0, err
+	}
+
+	// F_lambda = sqrt(f) * h^(-2/3) * sqrt(M0/M)
+	fT, h := T/T0, Rho0/Rho
+	F := math.Sqrt(fT) * math.Pow(h, -2.0/3.0) * math.Sqrt(ref.EOS[0].MolarMass/M)
+
+	// Critical enhancement of the fluid itself, with the default parameters
+	// apart from qD
+	lambdaCrit, err := ConductivityCritical(f, &fluid.ConductivityCrit{Type: "simplified_Olchowy_Sengers", QD: m.QD}, T, Rho)
+	if err != nil {
+		return 0, err
+	}
+
+	return lambdaInt + lambdaDilute + lambdaRef*F + lambdaCrit, nil
+}
+
+// conformalState solves for the state (T0, Rho0) of the reference fluid
+// with the same residual Helmholtz energy and compressibility factor as f
+// at (T, Rho). Newton starts from the ratios of the critical parameters and
+// then from the state with the same second virial term. In the dilute gas
+// the two conditions become nearly the same and may have no common
+// solution, but the reference fluid then only adds a small residual
+// contribution, so the best of the starting states is used instead.
+func conformalState(f, ref *fluid.FluidData, T, Rho float64) (T0, Rho0 float64, err error) {
+	state := core.NewState(f)
+	state.Update(T, Rho)
+	ar, ar_d, _, _, _, _ := state.Residual()
+	Z := 1 + state.Delta*ar_d
+
+	refState := core.NewState(ref)
+	funcJS := func(T0, Rho0 float64) (f1, f2, J11, J12, J21, J22 float64) {
+		refState.Update(T0, Rho0)
+		ar0, ar0_d, ar0_t, ar0_dd, _, ar0_dt := refState.Residual()
+		delta := refState.Delta
+		dtau_dT := -refState.Tau / T0
+		ddelta_drho := delta / Rho0
+
+		// Residuals: alphar and Z = 1 + delta*alphar_delta
+		f1 = ar0 - ar
+		f2 = 1 + delta*ar0_d - Z
+
+		J11 = ar0_t * dtau_dT
+		J12 = ar0_d * ddelta_drho
+		J21 = delta * ar0_dt * dtau_dT
+		J22 = (ar0_d + delta*ar0_dd) * ddelta_drho
+		return
+	}
+
+	// Starting states: critical ratios, and the same reduced second virial
+	// coefficient B*rhoc at the critical density ratio
+	hc := ref.States.Critical.RhoMolar / f.States.Critical.RhoMolar
+	seeds := [][2]float64{{T * ref.States.Critical.T / f.States.Critical.T, Rho * hc}}
+	B := secondVirial(state, T)
+	T0v, errv := solver.Brent(func(T0 float64) float64 {
+		return secondVirial(refState, T0) - B/hc
+	}, 0.3*ref.States.Critical.T, 5*ref.States.Critical.T, 1e-9)
+	if errv == nil {
+		seeds = append(seeds, [2]float64{T0v, Rho * hc})
+	}
+
+	var firstErr error
+	for _, seed := range seeds {
+		res, err := solver.Newton2DSolve(funcJS, seed[0], seed[1], solver.Newton2DOptions{
+			Tol:        1e-9,
+			MaxIter:    50,
+			MaxRelStep: 0.5,
+			Bounds:     &solver.Bounds2D{XMax: math.Inf(1), YMax: math.Inf(1)},
+		})
+		if err == nil {
+			return res.X, res.Y, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if Rho < ecsDiluteDelta*f.States.Critical.RhoMolar {
+		best, bestNorm := seeds[0], math.Inf(1)
+		for _, seed := range seeds {
+			f1, f2, _, _, _, _ := funcJS(seed[0], seed[1])
+			if norm := math.Hypot(f1, f2); norm < bestNorm {
+				best, bestNorm = seed, norm
+			}
+		}
+		return best[0], best[1], nil
+	}
+	return 0, 0, fmt.Errorf("conformal state of %s in %s at T=%v, rho=%v: %w", f.Info.Name, ref.Info.Name, T, Rho, firstErr)
+}
+
+// ecsDiluteDelta is the reduced density rho/rhoc below which conformalState
+// falls back to an approximate conformal state.
+const ecsDiluteDelta = 0.1
+
+// secondVirial returns the second virial coefficient in m³/mol of the fluid
+// of state at temperature T, leaving state at (T, ~0).
+func secondVirial(state *core.State, T float64) float64 {
+	const rho = 1e-6 // mol/m³
+	state.Update(T, rho)
+	_, ar_d, _, _, _, _ := state.Residual()
+	return ar_d * state.Delta / rho
+}
+
+// viscosityBackground returns the viscosity of ref in Pa*s without the
+// dilute gas part, from its first correlation that is not itself ECS or
+// hardcoded.
+func viscosityBackground(ref *fluid.FluidData, T, Rho float64) (float64, error) {
+	for i := range ref.Transport.Viscosity {
+		if m := &ref.Transport.Viscosity[i]; m.Type == "" && m.Hardcoded == "" {
+			muInit, err := ViscosityInitialDensity(ref, m, T, Rho)
+			if err != nil {
+				return 0, err
+			}
+			muRes, err := ViscosityResidual(ref, m, T, Rho)
+			if err != nil {
+				return 0, err
+			}
+			return muInit + muRes, nil
+		}
+	}
+	return 0, fmt.Errorf("no viscosity correlation for the ECS reference fluid %s", ref.Info.Name)
+}
+
+// conductivityBackground returns the residual thermal conductivity of ref
+// in W/(m*K), like viscosityBackground.
+func conductivityBackground(ref *fluid.FluidData, T, Rho float64) (float64, error) {
+	for i := range ref.Transport.Conductivity {
+		if m := &ref.Transport.Conductivity[i]; m.Type == "" && m.Hardcoded == "" {
+			return ConductivityResidual(ref, m, T, Rho)
+		}
+	}
+	return 0, fmt.Errorf("no conductivity correlation for the ECS reference fluid %s", ref.Info.Name)
+}
+
+// ecsPolynomial returns sum(a_i * x^t_i) of an ECS correction.
+func ecsPolynomial(c *fluid.ECSCorrection, x float64) float64 {
+	sum := 0.0
+	for i, a := range c.A {
+		sum += a * math.Pow(x, c.T[i])
+	}
+	return sum
+}
+
+// ---- Reference fluids ----
+
+type referenceEntry struct {
+	f   *fluid.FluidData
+	err error
+}
+
+var (
+	referenceMu     sync.Mutex
+	referenceFluids = map[string]referenceEntry{}
+)
+
+// referenceFluid loads the ECS reference fluid name from the directory f
+// was loaded from. Reference fluids are cached and must not be modified.
+func referenceFluid(f *fluid.FluidData, name string) (*fluid.FluidData, error) {
+	if name == "" {
+		return nil, fmt.Errorf("no ECS reference fluid for %s", f.Info.Name)
+	}
+	key := f.Dir + "|" + name
+
+	referenceMu.Lock()
+	defer referenceMu.Unlock()
+	if e, ok := referenceFluids[key]; ok {
+		return e.f, e.err
+	}
+	ref, err := fluid.LoadFluidByName(name, f.Dir)
+	if err != nil {
+		err = fmt.Errorf("ECS reference fluid %s of %s: %w", name, f.Info.Name, err)
+	}
+	referenceFluids[key] = referenceEntry{ref, err}
+	return ref, err
+}
@@ -0,0 +1,244 @@
+package transport
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/saturation"
+	"fmt"
+	"math"
+)
+
+// Corresponding states estimates of the transport properties for fluids
+// without a correlation. They only need the critical constants, acentric
+// factor and dipole moment, and are typically good to 5-10% in the gas and
+// worse in the liquid, so Viscosity and Conductivity use them only as a
+// last resort, or where the fluid data selects a "Chung" or "Lucas" model,
+// and ViscosityEstimated / ConductivityEstimated report it.
+
+// estimationParams holds the fluid constants of the estimation methods.
+type estimationParams struct {
+	Tc       float64 // K
+	Pc       float64 // Pa
+	Rhoc     float64 // mol/m³
+	Acentric float64
+	Dipole   float64 // Debye
+	Kappa    float64 // association factor
+	M        float64 // kg/mol
+}
+
+// estimatedModel reports whether a model of type typ is one of the
+// estimation methods rather than a correlation.
+func estimatedModel(typ string) bool {
+	return typ == "Chung" || typ == "Lucas"
+}
+
+// estimationParameters returns the constants of a "Chung" or "Lucas"
+// viscosity model if the fluid has one, otherwise those of the EOS with no
+// dipole moment.
+func estimationParameters(f *fluid.FluidData) estimationParams {
+	p := estimationParams{
+		Tc:       f.States.Critical.T,
+		Pc:       f.States.Critical.P,
+		Rhoc:     f.States.Critical.RhoMolar,
+		Acentric: f.EOS[0].Acentric,
+		M:        f.EOS[0].MolarMass,
+	}
+	for _, m := range f.Transport.Viscosity {
+		if !estimatedModel(m.Type) {
+			continue
+		}
+		if m.TCritical > 0 {
+			p.Tc = m.TCritical
+		}
+		if m.RhoMolarCritical > 0 {
+			p.Rhoc = m.RhoMolarCritical
+		}
+		if m.MolarMass > 0 {
+			p.M = m.MolarMass
+		}
+		p.Acentric = m.Acentric
+		p.Dipole = m.DipoleMoment
+		p.Kappa = m.Kappa
+		break
+	}
+	return p
+}
+
+// ViscosityEstimated reports whether Viscosity of f is an estimate rather
+// than a correlation of measured data.
+func ViscosityEstimated(f *fluid.FluidData) bool {
+	for _, m := range f.Transport.Viscosity {
+		if !estimatedModel(m.Type) {
+			return false
+		}
+	}
+	return true
+}
+
+// ConductivityEstimated reports whether Conductivity of f is an estimate
+// rather than a correlation of measured data.
+func ConductivityEstimated(f *fluid.FluidData) bool {
+	for _, m := range f.Transport.Conductivity {
+		if !estimatedModel(m.Type) {
+			return false
+		}
+	}
+	return true
+}
+
+// ---- Chung et al. (1988) ----
+
+// chungReduced returns the critical volume in cm³/mol, the reduced dipole
+// moment and the packing fraction y = rho*Vc/6 of the Chung method.
+func chungReduced(p estimationParams, Rho float64) (Vc, muR, y float64) {
+	Vc = 1e6 / p.Rhoc
+	muR = 131.3 * p.Dipole / math.Sqrt(Vc*p.Tc)
+	y = Rho / 1e6 * Vc / 6
+	return Vc, muR, y
+}
+
+// chungG2 returns the density function G2 of the Chung method with the
+// coefficients B1..B5 (1-based, B[0] unused). It tends to 1 as y -> 0.
+func chungG2(B []float64, y float64) float64 {
+	G1 := (1 - 0.5*y) / math.Pow(1-y, 3)
+	// (1 - exp(-B4*y)) / y -> B4 at zero density
+	ey := B[4]
+	if y > 0 {
+		ey = -math.Expm1(-B[4]*y) / y
+	}
+	return (B[1]*ey + B[2]*G1*math.Exp(B[5]*y) + B[3]*G1) / (B[1]*B[4] + B[2] + B[3])
+}
+
+// chungDiluteViscosity returns the dilute-gas viscosity in Pa*s of the Chung
+// method, the Chapman-Enskog result with sigma and epsilon/k estimated from
+// the critical point and a correction for shape and polarity.
+func chungDiluteViscosity(p estimationParams, T float64) float64 {
+	Vc, muR, _ := chungReduced(p, 0)
+	Tstar := 1.2593 * T / p.Tc
+	Fc := 1 - 0.2756*p.Acentric + 0.059035*math.Pow(muR, 4) + p.Kappa
+	omega := 1.16145*math.Pow(Tstar, -0.14874) + 0.52487*math.Exp(-0.77320*Tstar) + 2.16178*math.Exp(-2.43787*Tstar) -
+		6.435e-4*math.Pow(Tstar, 0.14874)*math.Sin(18.0323*math.Pow(Tstar, -0.76830)-7.27371)
+	Mg := p.M * 1000.0
+	return 4.0785e-6 * math.Sqrt(Mg*T) / (math.Pow(Vc, 2.0/3.0) * omega) * Fc
+}
+
+// ViscosityChung estimates the viscosity in Pa*s with the method of Chung
+// et al. (1988), including its dense-fluid terms.
+func ViscosityChung(f *fluid.FluidData, T, Rho float64) float64 {
+	return viscosityChung(estimationParameters(f), T, Rho)
+}
+
+func viscosityChung(p estimationParams, T, Rho float64) float64 {
+	a0 := [...]float64{0, 6.32402, 0.12102e-2, 5.28346, 6.62263, 19.74540, -1.89992, 24.27450, 0.79716, -0.23816, 0.68629e-1}
+	a1 := [...]float64{0, 50.41190, -0.11536e-2, 254.20900, 38.09570, 7.63034, -12.53670, 3.44945, 1.11764, 0.67695e-1, 0.34793}
+	a2 := [...]float64{0, -51.68010, -0.62571e-2, -168.48100, -8.46414, -14.35440, 4.98529, -11.29130, 0.12348e-1, -0.81630, 0.59256}
+	a3 := [...]float64{0, 1189.02000, 0.37283e-1, 3898.27000, 31.41780, 31.52670, -18.15070, 69.34660, -4.11661, 4.02528, -0.72663}
+
+	Vc, muR, y := chungReduced(p, Rho)
+	A := make([]float64, 11)
+	for i := 1; i <= 10; i++ {
+		A[i] = a0[i] + a1[i]*p.Acentric + a2[i]*math.Pow(muR, 4) + a3[i]*p.Kappa
+	}
+	Tstar := 1.2593 * T / p.Tc
+	G2 := chungG2(A, y)
+
+	eta0 := chungDiluteViscosity(p, T)
+	etaK := eta0 * (1/G2 + A[6]*y)
+	etaP := 3.6344e-6 * math.Sqrt(p.M*1000.0*p.Tc) / math.Pow(Vc, 2.0/3.0) *
+		A[7] * y * y * G2 * math.Exp(A[8]+A[9]/Tstar+A[10]/(Tstar*Tstar))
+	return etaK + etaP
+}
+
+// ConductivityChung estimates the thermal conductivity in W/(m*K) with the
+// method of Chung et al. (1988), using the ideal-gas heat capacity of the
+// EOS for the internal degrees of freedom.
+func ConductivityChung(f *fluid.FluidData, T, Rho float64) float64 {
+	p := estimationParameters(f)
+
+	a := [...]float64{0, 2.4166, -0.50924, 6.6107, 14.543, 0.79274, -5.8634, 91.089}
+	b := [...]float64{0, 0.74824, -1.5094, 5.6207, -8.9139, 0.82019, 12.801, 128.11}
+	c := [...]float64{0, -0.91858, -49.991, 64.760, -5.6379, -0.69369, 9.5893, -54.217}
+	d := [...]float64{0, 121.72, 69.983, 27.039, 74.344, 6.3173, 65.529, 523.81}
+
+	Vc, muR, y := chungReduced(p, Rho)
+	B := make([]float64, 8)
+	for i := 1; i <= 7; i++ {
+		B[i] = a[i] + b[i]*p.Acentric + c[i]*math.Pow(muR, 4) + d[i]*p.Kappa
+	}
+	G2 := chungG2(B, y)
+	Tr := T / p.Tc
+
+	// Psi corrects the Eucken factor for the internal degrees of freedom,
+	// alpha = cv0/R - 3/2
+	alpha := -idealGasTau2(f, T) - 1.5
+	beta := 0.7862 - 0.7109*p.Acentric + 1.3168*p.Acentric*p.Acentric
+	Z := 2.0 + 10.5*Tr*Tr
+	psi := 1 + alpha*(0.215+0.28288*alpha-1.061*beta+0.26665*Z)/(0.6366+beta*Z+1.061*alpha*beta)
+
+	eta0 := chungDiluteViscosity(p, T)
+	q := 3.586e-3 * math.Sqrt(p.Tc/p.M) / math.Pow(Vc, 2.0/3.0)
+
+	return 31.2*eta0*psi/p.M*(1/G2+B[6]*y) + q*B[7]*y*y*math.Sqrt(Tr)*G2
+}
+
+// ---- Lucas (1980) ----
+
+// ViscosityLucas estimates the viscosity in Pa*s with the corresponding
+// states method of Lucas, as given by Poling et al. (2001). It is a method
+// for gases, dense ones included, and gives an error for liquid states; the
+// quantum correction for helium and hydrogen is not applied.
+func ViscosityLucas(f *fluid.FluidData, T, Rho float64) (float64, error) {
+	p := estimationParameters(f)
+
+	Pc := p.Pc / 1e5 // bar
+	Mg := p.M * 1000.0
+	Tr := T / p.Tc
+	if Tr < 1 {
+		rhoV, err := saturation.RhoV(f, T)
+		if err != nil {
+			return 0, err
+		}
+		if Rho > rhoV {
+			return 0, fmt.Errorf("Lucas viscosity of %s is for gases only, not at T=%g K, rho=%g mol/m³", f.Info.Name, T, Rho)
+		}
+	}
+	Zc := p.Pc / (p.Rhoc * f.EOS[0].GasConstant * p.Tc)
+
+	// Polarity correction from the reduced dipole moment
+	muR := 52.46 * p.Dipole * p.Dipole * Pc / (p.Tc * p.Tc)
+	Fp0 := 1.0
+	if muR >= 0.022 {
+		Fp0 = 1 + 30.55*math.Pow(0.292-Zc, 1.72)
+		if muR >= 0.075 {
+			Fp0 = 1 + 30.55*math.Pow(0.292-Zc, 1.72)*math.Abs(0.96+0.1*(Tr-0.7))
+		}
+	}
+
+	// Low-pressure gas, eta*xi with xi in 1/microPoise
+	xi := 0.176 * math.Pow(p.Tc/(Mg*Mg*Mg*Pc*Pc*Pc*Pc), 1.0/6.0)
+	Z1 := (0.807*math.Pow(Tr, 0.618) - 0.357*math.Exp(-0.449*Tr) + 0.340*math.Exp(-4.058*Tr) + 0.018) * Fp0
+
+	state := core.NewState(f)
+	state.Update(T, Rho)
+	Pr := state.Pressure() / p.Pc
+
+	var Z2 float64
+	if Tr <= 1 {
+		alpha := 3.262 + 14.98*math.Pow(Pr, 5.508)
+		beta := 1.390 + 5.746*Pr
+		Z2 = 0.600 + 0.760*math.Pow(Pr, alpha) + (6.990*math.Pow(Pr, beta)-0.6)*(1-Tr)
+	} else {
+		a := 1.245e-3 / Tr * math.Exp(5.1726*math.Pow(Tr, -0.3286))
+		b := a * (1.6553*Tr - 1.2723)
+		c := 0.4489 / Tr * math.Exp(3.0578*math.Pow(Tr, -37.7332))
+		d := 1.7368 / Tr * math.Exp(2.2310*math.Pow(Tr, -7.6351))
+		e := 1.3088
+		fe := 0.9425 * math.Exp(-0.1853*math.Pow(Tr, 0.4489))
+		Z2 = Z1 * (1 + a*math.Pow(Pr, e)/(b*math.Pow(Pr, fe)+1/(1+c*math.Pow(Pr, d))))
+	}
+
+	Y := Z2 / Z1
+	Fp := (1 + (Fp0-1)*math.Pow(Y, -3)) / Fp0
+
+	return Z2 * Fp / xi * 1e-7, nil // microPoise -> Pa*s
+}
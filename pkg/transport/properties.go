@@ -0,0 +1,49 @@
+package transport
+
+import (
+	"GOcoolprop/pkg/core"
+	"GOcoolprop/pkg/fluid"
+)
+
+// Properties holds the transport properties of one state and the
+// dimensionless groups and diffusivities built from them.
+type Properties struct {
+	Viscosity          float64 // Pa*s
+	Conductivity       float64 // W/(m*K)
+	KinematicViscosity float64 // m²/s
+	ThermalDiffusivity float64 // m²/s
+	Prandtl            float64
+
+	// Estimated is set if the viscosity or conductivity is an estimate, see
+	// ViscosityEstimated and ConductivityEstimated.
+	Estimated bool
+}
+
+// Evaluate calculates all transport properties at temperature T (K) and
+// molar density Rho (mol/m³), evaluating the EOS once for the density and
+// heat capacity.
+func Evaluate(f *fluid.FluidData, T, Rho float64) (Properties, error) {
+	mu, err := Viscosity(f, T, Rho)
+	if err != nil {
+		return Properties{}, err
+	}
+	lambda, err := Conductivity(f, T, Rho)
+	if err != nil {
+		return Properties{}, err
+	}
+
+	state := core.NewState(f)
+	state.Update(T, Rho)
+	M := f.EOS[0].MolarMass
+	rhoMass := Rho * M   // kg/m³
+	cp := state.Cp() / M // J/(kg*K)
+
+	return Properties{
+		Viscosity:          mu,
+		Conductivity:       lambda,
+		KinematicViscosity: mu / rhoMass,
+		ThermalDiffusivity: lambda / (rhoMass * cp),
+		Prandtl:            mu * cp / lambda,
+		Estimated:          ViscosityEstimated(f) || ConductivityEstimated(f),
+	}, nil
+}
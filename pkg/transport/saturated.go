@@ -0,0 +1,44 @@
+package transport
+
+import (
+	"GOcoolprop/pkg/fluid"
+	"GOcoolprop/pkg/saturation"
+	"fmt"
+)
+
+// SaturatedLiquid calculates the transport properties of the saturated
+// liquid at temperature T (K), e.g. for boiling and condensation
+// correlations. Inside the dome these, not the values at the mixture
+// density, are the meaningful ones.
+func SaturatedLiquid(f *fluid.FluidData, T float64) (Properties, error) {
+	if err := checkSaturated(f, T); err != nil {
+		return Properties{}, err
+	}
+	rhoL, err := saturation.RhoL(f, T)
+	if err != nil {
+		return Properties{}, err
+	}
+	return Evaluate(f, T, rhoL)
+}
+
+// SaturatedVapor calculates the transport properties of the saturated
+// vapour at temperature T (K), like SaturatedLiquid.
+func SaturatedVapor(f *fluid.FluidData, T float64) (Properties, error) {
+	if err := checkSaturated(f, T); err != nil {
+		return Properties{}, err
+	}
+	rhoV, err := saturation.RhoV(f, T)
+	if err != nil {
+		return Properties{}, err
+	}
+	return Evaluate(f, T, rhoV)
+}
+
+// checkSaturated checks that T lies on the saturation curve of f.
+func checkSaturated(f *fluid.FluidData, T float64) error {
+	if T < f.EOS[0].TTriple || T >= f.States.Critical.T {
+		return fmt.Errorf("no saturated states of %s at T=%g K, outside the triple point %g K to the critical point %g K",
+			f.Info.Name, T, f.EOS[0].TTriple, f.States.Critical.T)
+	}
+	return nil
+}
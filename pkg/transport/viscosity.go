@@ -1,39 +1,92 @@
 package transport
 
 import (
+	"GOcoolprop/pkg/core"
 	"GOcoolprop/pkg/fluid"
+	"errors"
 	"fmt"
 	"math"
 )
 
-// Viscosity calculates the viscosity in Pa*s.
+// Viscosity calculates the viscosity in Pa*s with the first model in
+// f.Transport.Viscosity that can be evaluated, falling back to the next on
+// an error. If none can, the errors of all models are returned. Fluids
+// without viscosity data get the Chung estimate, see ViscosityEstimated.
 func Viscosity(f *fluid.FluidData, T, Rho float64) (float64, error) {
+	models := f.Transport.Viscosity
+	if len(models) == 0 {
+		return ViscosityChung(f, T, Rho), nil
+	}
+
+	var errs []error
+	for i := range models {
+		mu, err := ViscosityModel(f, i, T, Rho)
+		if err == nil {
+			return mu, nil
+		}
+		errs = append(errs, err)
+	}
+	return 0, errors.Join(errs...)
+}
+
+// ViscosityModel calculates the viscosity in Pa*s with model i of
+// f.Transport.Viscosity, without falling back to the others.
+func ViscosityModel(f *fluid.FluidData, i int, T, Rho float64) (float64, error) {
+	if i < 0 || i >= len(f.Transport.Viscosity) {
+		return 0, fmt.Errorf("%s has no viscosity model %d", f.Info.Name, i)
+	}
+	m := &f.Transport.Viscosity[i]
+
 	// Check for hardcoded fluids (e.g. Water)
-	if f.Transport.Viscosity.Hardcoded != "" {
-		return 0, fmt.Errorf("hardcoded viscosity for %s not implemented yet", f.Info.Name)
+	if m.Hardcoded != "" {
+		return viscosityHardcoded(f, m.Hardcoded, T, Rho)
+	}
+	switch m.Type {
+	case "":
+	case "ECS":
+		return viscosityECS(f, m, T, Rho)
+	case "rhosr-CS":
+		return viscosityRhoSr(f, m, T, Rho)
+	case "Chung":
+		return ViscosityChung(f, T, Rho), nil
+	case "Lucas":
+		return ViscosityLucas(f, T, Rho)
+	default:
+		return 0, fmt.Errorf("%s viscosity model for %s not implemented yet", m.Type, f.Info.Name)
 	}
 
 	// 1. Dilute Gas Contribution
-	mu0, err := ViscosityDilute(f, T)
+	mu0, err := ViscosityDilute(f, m, T)
 	if err != nil {
 		return 0, err
 	}
 
-	// 2. Residual / Higher Order Contribution
-	muRes, err := ViscosityResidual(f, T, Rho)
+	// 2. Initial Density Contribution
+	muInit, err := ViscosityInitialDensity(f, m, T, Rho)
 	if err != nil {
 		return 0, err
 	}
 
-	return mu0 + muRes, nil
+	// 3. Residual / Higher Order Contribution
+	muRes, err := ViscosityResidual(f, m, T, Rho)
+	if err != nil {
+		return 0, err
+	}
+
+	return mu0 + muInit + muRes, nil
 }
 
-func ViscosityDilute(f *fluid.FluidData, T float64) (float64, error) {
-	d := f.Transport.Viscosity.Dilute
+// ViscosityDilute calculates the dilute-gas viscosity of model m in Pa*s.
+func ViscosityDilute(f *fluid.FluidData, m *fluid.ViscosityData, T float64) (float64, error) {
+	d := m.Dilute
 	if d == nil {
 		return 0, nil // No dilute term?
 	}
 
+	if d.Hardcoded != "" {
+		return viscosityDiluteHardcoded(f, d.Hardcoded, T)
+	}
+
 	if d.Type == "collision_integral" {
 		// mu0 = C * sqrt(M*T) / (sigma^2 * Omega)
 		// Units:
@@ -46,15 +99,15 @@ func ViscosityDilute(f *fluid.FluidData, T float64) (float64, error) {
 		Mg := d.MolarMass * 1000.0
 
 		// Convert sigma to nm
-		sigma_nm := f.Transport.Viscosity.SigmaEta * 1e9
+		sigma_nm := m.SigmaEta * 1e9
 
-		Tstar := T / f.Transport.Viscosity.EpsilonOverK
+		Tstar := T / m.EpsilonOverK
 
-		// Omega(T*) = exp(sum(a_i * (ln T*)^i))
+		// Omega(T*) = exp(sum(a_i * (ln T*)^t_i))
 		lnT := math.Log(Tstar)
 		sum := 0.0
 		for i, a := range d.A {
-			sum += a * math.Pow(lnT, float64(i))
+			sum += a * math.Pow(lnT, d.T[i])
 		}
 		omega := math.Exp(sum)
 
@@ -64,15 +117,93 @@ func ViscosityDilute(f *fluid.FluidData, T float64) (float64, error) {
 		return mu0, nil
 	}
 
+	if d.Type == "collision_integral_powers_of_Tstar" {
+		// mu0 = C * sqrt(T) / sum(a_i * T*^t_i), T* = T / T_reducing
+		Tstar := T / d.TReducing
+		sum := 0.0
+		for i, a := range d.A {
+			sum += a * math.Pow(Tstar, d.T[i])
+		}
+		return d.C * math.Sqrt(T) / sum, nil
+	}
+
+	if d.Type == "powers_of_T" || d.Type == "powers_of_Tr" {
+		// mu0 = sum(a_i * T^t_i), or of Tr = T / T_reducing
+		Tr := T
+		if d.Type == "powers_of_Tr" {
+			Tr = T / d.TReducing
+		}
+		sum := 0.0
+		for i, a := range d.A {
+			sum += a * math.Pow(Tr, d.T[i])
+		}
+		return sum, nil
+	}
+
+	if d.Type == "kinetic_theory" {
+		// Chapman-Enskog with the Lennard-Jones parameters of the model
+		return viscosityKineticTheory(f.EOS[0].MolarMass, m.SigmaEta, m.EpsilonOverK, T), nil
+	}
+
 	return 0, fmt.Errorf("unknown dilute viscosity type: %s", d.Type)
 }
 
-func ViscosityResidual(f *fluid.FluidData, T, Rho float64) (float64, error) {
-	h := f.Transport.Viscosity.HigherOrder
+// ViscosityInitialDensity calculates the initial-density viscosity of model
+// m in Pa*s, the contribution linear in density.
+func ViscosityInitialDensity(f *fluid.FluidData, m *fluid.ViscosityData, T, Rho float64) (float64, error) {
+	d := m.Initial
+	if d == nil {
+		return 0, nil
+	}
+
+	if d.Type == "Rainwater-Friend" {
+		// mu_init = mu0 * B_eta * rho with the second viscosity virial
+		// coefficient B_eta = N_A * sigma^3 * B*(T*), B*(T*) = sum(b_i * T*^t_i)
+		mu0, err := ViscosityDilute(f, m, T)
+		if err != nil {
+			return 0, err
+		}
+
+		sigma, epsilonOverK := lennardJones(f, m)
+		Tstar := T / epsilonOverK
+
+		Bstar := 0.0
+		for i, b := range d.B {
+			Bstar += b * math.Pow(Tstar, d.T[i])
+		}
+		Beta := avogadro * sigma * sigma * sigma * Bstar // m³/mol
+
+		return mu0 * Beta * Rho, nil
+	}
+
+	if d.Type == "empirical" {
+		// mu_init = sum(n_i * delta^d_i * tau^t_i)
+		delta := Rho / d.RhoMolarReducing
+		tau := d.TReducing / T
+
+		sum := 0.0
+		for i := range d.N {
+			sum += d.N[i] * math.Pow(delta, d.D[i]) * math.Pow(tau, d.T[i])
+		}
+
+		return sum, nil
+	}
+
+	return 0, fmt.Errorf("unknown initial density viscosity type: %s", d.Type)
+}
+
+// ViscosityResidual calculates the higher-order (density dependent)
+// viscosity of model m in Pa*s.
+func ViscosityResidual(f *fluid.FluidData, m *fluid.ViscosityData, T, Rho float64) (float64, error) {
+	h := m.HigherOrder
 	if h == nil {
 		return 0, nil
 	}
 
+	if h.Hardcoded != "" {
+		return viscosityResidualHardcoded(f, h.Hardcoded, T, Rho)
+	}
+
 	if h.Type == "modified_Batschinski_Hildebrand" {
 		// Reference: Lemmon and Jacobsen (2004) for Nitrogen
 		// mu_res = sum(a_i * delta^d1_i * tau^t1_i * exp(gamma_i * delta^l_i))
@@ -93,8 +224,108 @@ func ViscosityResidual(f *fluid.FluidData, T, Rho float64) (float64, error) {
 			sum += term
 		}
 
+		// Free-volume term F * (1/(delta0 - delta) - 1/delta0) with
+		// F = sum(f_i * delta^d2_i * tau^t2_i) and the close-packed density
+		// delta0 = sum(g_i * tau^h_i) / sum(p_i * tau^q_i)
+		if len(h.F) > 0 {
+			F := 0.0
+			for i := range h.F {
+				F += h.F[i] * math.Pow(delta, h.D2[i]) * math.Pow(tau, h.T2[i])
+			}
+			num, den := 0.0, 0.0
+			for i := range h.G {
+				num += h.G[i] * math.Pow(tau, h.H[i])
+			}
+			for i := range h.P {
+				den += h.P[i] * math.Pow(tau, h.Q[i])
+			}
+			delta0 := num / den
+			sum += F * (1/(delta0-delta) - 1/delta0)
+		}
+
 		return sum, nil
 	}
 
+	if h.Type == "friction_theory" {
+		return viscosityFrictionTheory(f, h, T, Rho), nil
+	}
+
 	return 0, fmt.Errorf("unknown residual viscosity type: %s", h.Type)
 }
+
+// viscosityFrictionTheory returns the residual viscosity in Pa*s of the
+// friction theory of Quinones-Cisneros and Deiters (2006): a quadratic (or
+// cubic) form in the attractive and repulsive pressures of the EOS.
+func viscosityFrictionTheory(f *fluid.FluidData, h *fluid.ViscosityHigher, T, Rho float64) float64 {
+	tau := h.TReduce / T
+	psi1 := math.Exp(tau) - h.C1
+	psi2 := math.Exp(tau*tau) - h.C2
+
+	// k = (A_0 + A_1*psi1 + A_2*psi2) * tau^N, zero if A is absent
+	k := func(A []float64, N float64) float64 {
+		if len(A) == 0 {
+			return 0
+		}
+		return (A[0] + A[1]*psi1 + A[2]*psi2) * math.Pow(tau, N)
+	}
+	ki := k(h.Ai, 1)
+	ka := k(h.Aa, h.Na)
+	kr := k(h.Ar, h.Nr)
+	kaa := k(h.Aaa, h.Naa)
+	krr := k(h.Arr, h.Nrr)
+	kdrdr := 0.0
+	if len(h.Arr) == 0 {
+		kdrdr = k(h.Adrdr, h.Nrr)
+	}
+	kii := k(h.Aii, h.Nii)
+	krrr, kaaa := 0.0, 0.0
+	if len(h.Arrr) > 0 && len(h.Aaaa) > 0 {
+		krrr = k(h.Arrr, h.Nrrr)
+		kaaa = k(h.Aaaa, h.Naaa)
+	}
+
+	// Repulsive pressure T*(dP/dT)_rho, attractive pressure P - pr and ideal
+	// gas pressure, all in bar
+	state := core.NewState(f)
+	state.Update(T, Rho)
+	p := state.Pressure() / 1e5
+	pr := T * state.DPdT() / 1e5
+	pa := p - pr
+	pid := Rho * f.EOS[0].GasConstant * T / 1e5
+	dpr := pr - pid
+
+	return ka*pa + kr*dpr + ki*pid + kaa*pa*pa + kdrdr*dpr*dpr + krr*pr*pr + kii*pid*pid +
+		krrr*pr*pr*pr + kaaa*pa*pa*pa
+}
+
+// lennardJones returns the Lennard-Jones sigma (m) and epsilon/k (K) of
+// model m, or, if it has none, the estimates of Chung et al. (1988) from the
+// critical point.
+func lennardJones(f *fluid.FluidData, m *fluid.ViscosityData) (sigma, epsilonOverK float64) {
+	if m != nil && m.SigmaEta > 0 && m.EpsilonOverK > 0 {
+		return m.SigmaEta, m.EpsilonOverK
+	}
+	// sigma [nm] = 0.809 / rhoc[mol/L]^(1/3), epsilon/k = Tc / 1.2593, from
+	// the reducing state of the EOS as in CoolProp, whose values the rhosr-CS
+	// coefficients were fitted with
+	Tc := f.EOS[0].States.Reducing.T
+	rhoc := f.EOS[0].States.Reducing.RhoMolar / 1000.0
+	if Tc == 0 || rhoc == 0 {
+		Tc = f.States.Critical.T
+		rhoc = f.States.Critical.RhoMolar / 1000.0
+	}
+	return 0.809 / math.Cbrt(rhoc) * 1e-9, Tc / 1.2593
+}
+
+// viscosityKineticTheory returns the dilute-gas viscosity in Pa*s of a
+// Lennard-Jones fluid with molar mass M (kg/mol), sigma (m) and epsilon/k
+// (K), using the collision integral of Neufeld et al. (1972).
+func viscosityKineticTheory(M, sigma, epsilonOverK, T float64) float64 {
+	Tstar := T / epsilonOverK
+	sigma_nm := sigma * 1e9
+	Mg := M * 1000.0
+
+	omega22 := 1.16145*math.Pow(Tstar, -0.14874) + 0.52487*math.Exp(-0.77320*Tstar) + 2.16178*math.Exp(-2.43787*Tstar)
+
+	return 26.692e-9 * math.Sqrt(Mg*T) / (sigma_nm * sigma_nm * omega22)
+}